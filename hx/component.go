@@ -0,0 +1,183 @@
+package hx
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/QuangTung97/weblib/null"
+)
+
+// Component is a reusable element with props. Children passed to Component.Render are split into:
+// attributes of the caller, named slots created by Slot, and other children
+type Component[P any] struct {
+	defaults P
+	render   func(props P, in ComponentInput) Elem
+}
+
+// NewComponent creates a component, zero fields of props are filled by the defaults.
+// So zero values, e.g. false, 0 or "", can not be passed over non-zero defaults of normal fields.
+// Props with meaningful zero values should use null.Null fields, they are only filled when not Valid,
+// e.g. null.New(false) is kept over the default null.New(true).
+// If P is not a struct, the defaults are used when props is the zero value
+func NewComponent[P any](defaults P, render func(props P, in ComponentInput) Elem) Component[P] {
+	return Component[P]{
+		defaults: defaults,
+		render:   render,
+	}
+}
+
+func (c Component[P]) Render(props P, children ...Elem) Elem {
+	in := ComponentInput{
+		slots: map[string][]Elem{},
+	}
+	in.addChildren(children)
+
+	return c.render(mergeDefaultProps(props, c.defaults), in)
+}
+
+// Slot is a named child of components, e.g. hx.Slot("footer", hx.Button(...)).
+// It is rendered as a group when used outside of components
+func Slot(name string, children ...Elem) Elem {
+	return Elem{
+		elemType: elemTypeSlot,
+		name:     []byte(name),
+		children: slices.Values(children),
+	}
+}
+
+// ComponentInput contains children passed to a component
+type ComponentInput struct {
+	attrs    []Elem
+	slots    map[string][]Elem
+	children []Elem
+}
+
+// Slot returns children of the named slot, or None if the slot is not passed
+func (in ComponentInput) Slot(name string) Elem {
+	return in.SlotOr(name, None())
+}
+
+// SlotOr returns children of the named slot, or the fallback if the slot is not passed
+func (in ComponentInput) SlotOr(name string, fallback Elem) Elem {
+	children, ok := in.slots[name]
+	if !ok {
+		return fallback
+	}
+	return Group(children...)
+}
+
+func (in ComponentInput) HasSlot(name string) bool {
+	_, ok := in.slots[name]
+	return ok
+}
+
+// Children returns children not belonging to any slot
+func (in ComponentInput) Children() Elem {
+	return Group(in.children...)
+}
+
+// MergeAttrs merges base attributes of the component with attributes of the caller.
+// Classes are joined like ClassGroup, for other attributes the last one wins
+func (in ComponentInput) MergeAttrs(base ...Elem) Elem {
+	var names []string
+	lastAttrs := map[string]Elem{}
+	var classes []string
+
+	for _, attr := range flattenAttrs(append(slices.Clone(base), in.attrs...)) {
+		name := string(attr.name)
+		if name == "class" && attr.elemType == elemTypeAttribute {
+			if len(attr.value) > 0 {
+				classes = append(classes, string(attr.value))
+			}
+		}
+
+		if _, existed := lastAttrs[name]; !existed {
+			names = append(names, name)
+		}
+		lastAttrs[name] = attr
+	}
+
+	result := make([]Elem, 0, len(names))
+	for _, name := range names {
+		if name == "class" {
+			// class values are already escaped
+			result = append(result, NewUnsafeAttr("class", strings.Join(classes, " ")))
+			continue
+		}
+		result = append(result, lastAttrs[name])
+	}
+	return Group(result...)
+}
+
+// ---------------------------------------------------------------------------
+// Internal Implementation
+// ---------------------------------------------------------------------------
+
+func (in *ComponentInput) addChildren(children []Elem) {
+	for _, child := range children {
+		switch child.elemType {
+		case elemTypeAttribute, elemTypeEmptyAttribute:
+			in.attrs = append(in.attrs, child)
+
+		case elemTypeSlot:
+			name := string(child.name)
+			in.slots[name] = append(in.slots[name], slices.Collect(child.children)...)
+
+		case elemTypeGroup:
+			in.addChildren(slices.Collect(child.children))
+
+		case elemTypeNone:
+			// ignored
+
+		default:
+			in.children = append(in.children, child)
+		}
+	}
+}
+
+// flattenAttrs returns attributes and empty attributes, including ones inside groups
+func flattenAttrs(elems []Elem) []Elem {
+	var result []Elem
+	for _, e := range elems {
+		switch e.elemType {
+		case elemTypeAttribute, elemTypeEmptyAttribute:
+			result = append(result, e)
+		case elemTypeGroup:
+			result = append(result, flattenAttrs(slices.Collect(e.children))...)
+		default:
+		}
+	}
+	return result
+}
+
+func mergeDefaultProps[P any](props P, defaults P) P {
+	propsValue := reflect.ValueOf(&props).Elem()
+	defaultsValue := reflect.ValueOf(defaults)
+
+	if propsValue.Kind() != reflect.Struct {
+		if propsValue.IsZero() {
+			return defaults
+		}
+		return props
+	}
+
+	for i := range propsValue.NumField() {
+		field := propsValue.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		if nullOutput, isNull := null.IsNullType(field); isNull {
+			if !nullOutput.NonNull {
+				field.Set(defaultsValue.Field(i))
+			}
+			continue
+		}
+
+		if field.IsZero() {
+			field.Set(defaultsValue.Field(i))
+		}
+	}
+	return props
+}
@@ -0,0 +1,116 @@
+package hx
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/null"
+)
+
+type cardProps struct {
+	Title   string
+	Variant string
+	Width   int
+}
+
+var testCard = NewComponent(
+	cardProps{Variant: "primary", Width: 300},
+	func(props cardProps, in ComponentInput) Elem {
+		return Div(
+			in.MergeAttrs(
+				Class("card card-"+props.Variant),
+				DataAttr("width", "300"),
+				Role("region"),
+			),
+			H2(Text(props.Title)),
+			Div(Class("card-body"), in.Children()),
+			in.SlotOr("footer", P(Text("default footer"))),
+		)
+	},
+)
+
+func TestComponent(t *testing.T) {
+	t.Run("default props and slots", func(t *testing.T) {
+		elem := testCard.Render(cardProps{Title: "Users"}, Text("content"))
+		assertSimpleContent(t,
+			`<div class="card card-primary" data-width="300" role="region"><h2>Users</h2>`+
+				`<div class="card-body">content</div><p>default footer</p></div>`,
+			elem,
+		)
+	})
+
+	t.Run("merge attrs and named slots", func(t *testing.T) {
+		elem := testCard.Render(
+			cardProps{Title: "Users", Variant: "danger"},
+			Class("mt-2"),
+			Group(ID("user-card"), Role("alert")),
+			Hidden(),
+			Slot("footer", Button(Text("Save")), Button(Text("Cancel"))),
+			Text("a"),
+			Group(Text("b")),
+			None(),
+		)
+		assertSimpleContent(t,
+			`<div class="card card-danger mt-2" data-width="300" role="alert" id="user-card" hidden><h2>Users</h2>`+
+				`<div class="card-body">ab</div><button>Save</button><button>Cancel</button></div>`,
+			elem,
+		)
+	})
+
+	t.Run("has slot", func(t *testing.T) {
+		var hasHeader, hasFooter bool
+		c := NewComponent(0, func(props int, in ComponentInput) Elem {
+			hasHeader = in.HasSlot("header")
+			hasFooter = in.HasSlot("footer")
+			return in.Slot("header")
+		})
+
+		elem := c.Render(0, Slot("footer"))
+		assertSimpleContent(t, ``, elem)
+		assert.Equal(t, false, hasHeader)
+		assert.Equal(t, true, hasFooter)
+	})
+
+	t.Run("non struct props", func(t *testing.T) {
+		c := NewComponent("default", func(props string, in ComponentInput) Elem {
+			return Span(Text(props))
+		})
+		assertSimpleContent(t, `<span>default</span>`, c.Render(""))
+		assertSimpleContent(t, `<span>custom</span>`, c.Render("custom"))
+	})
+
+	t.Run("null props keep zero values", func(t *testing.T) {
+		type buttonProps struct {
+			Label    string
+			Enabled  null.Null[bool]
+			Tabindex null.Null[int]
+		}
+		c := NewComponent(
+			buttonProps{Label: "OK", Enabled: null.New(true), Tabindex: null.New(1)},
+			func(props buttonProps, in ComponentInput) Elem {
+				disabled := None()
+				if !props.Enabled.Data {
+					disabled = Disabled()
+				}
+				return Button(
+					TabIndex(strconv.Itoa(props.Tabindex.Data)),
+					disabled,
+					Text(props.Label),
+				)
+			},
+		)
+
+		assertSimpleContent(t, `<button tabindex="1">OK</button>`, c.Render(buttonProps{}))
+		assertSimpleContent(t,
+			`<button tabindex="0" disabled>Save</button>`,
+			c.Render(buttonProps{Label: "Save", Enabled: null.New(false), Tabindex: null.New(0)}),
+		)
+	})
+
+	t.Run("slot outside of components", func(t *testing.T) {
+		elem := Div(Slot("footer", Text("a"), Text("b")))
+		assertSimpleContent(t, `<div>ab</div>`, elem)
+	})
+}
@@ -1,5 +1,7 @@
 package hx
 
+//go:generate go run ./internal/gen
+
 import (
 	"html"
 	"iter"
@@ -25,6 +27,29 @@ func Collect(seq iter.Seq[Elem]) Elem {
 	}
 }
 
+// CollectWithError is similar to Collect, but stops rendering when the iterator yields an error.
+// The error is returned from Elem.Render
+func CollectWithError(seq iter.Seq2[Elem, error]) Elem {
+	return Elem{
+		elemType: elemTypeIter,
+		children: func(yield func(Elem) bool) {
+			for child, err := range seq {
+				if err != nil {
+					yield(Elem{
+						elemType: elemTypeError,
+						extra:    &elemExtraInfo{renderErr: err},
+					})
+					return
+				}
+
+				if !yield(child) {
+					return
+				}
+			}
+		},
+	}
+}
+
 func ClassGroup(children ...Elem) Elem {
 	var buf strings.Builder
 	var counter int
@@ -47,44 +72,9 @@ func ClassGroup(children ...Elem) Elem {
 	return Class(buf.String())
 }
 
-// ----------------------------------------------------
-// Normal Tags
-// ----------------------------------------------------
-
-func Div(children ...Elem) Elem {
-	return NewNormalTag("div", children...)
-}
-
-func Button(children ...Elem) Elem {
-	return NewNormalTag("button", children...)
-}
-
-func A(children ...Elem) Elem {
-	return NewNormalTag("a", children...)
-}
-
-func Script(children ...Elem) Elem {
-	return NewNormalTag("script", children...)
-}
-
-func Ul(children ...Elem) Elem {
-	return NewNormalTag("ul", children...)
-}
-
-func Li(children ...Elem) Elem {
-	return NewNormalTag("li", children...)
-}
-
-func Select(children ...Elem) Elem {
-	return NewNormalTag("select", children...)
-}
-
-func Option(children ...Elem) Elem {
-	return NewNormalTag("option", children...)
-}
-
 // ----------------------------------------------------
 // Attributes
+// Other elements and attributes are generated in element_gen.go
 // ----------------------------------------------------
 
 func Class(className string) Elem {
@@ -100,17 +90,27 @@ func Name(val string) Elem {
 }
 
 func Href(urlPath string) Elem {
-	return NewUnsafeAttr("href", urlPath)
+	return NewURLAttr("href", urlPath)
 }
 
 func Src(urlPath string) Elem {
-	return NewUnsafeAttr("src", urlPath)
+	return NewURLAttr("src", urlPath)
 }
 
 func Rel(value string) Elem {
 	return NewNormalAttr("rel", value)
 }
 
+// DataAttr renders the attribute data-{name}
+func DataAttr(name string, value string) Elem {
+	return NewNormalAttr("data-"+name, value)
+}
+
+// AriaAttr renders the attribute aria-{name}
+func AriaAttr(name string, value string) Elem {
+	return NewNormalAttr("aria-"+name, value)
+}
+
 func Text(text string) Elem {
 	return Elem{
 		elemType: elemTypeContent,
@@ -118,34 +118,6 @@ func Text(text string) Elem {
 	}
 }
 
-// ----------------------------------------------------
-// Simple Tag
-// ----------------------------------------------------
-
-func Br() Elem {
-	return NewSimpleTag("br")
-}
-
-func Input(children ...Elem) Elem {
-	return NewSimpleTag("input", children...)
-}
-
-func Link(children ...Elem) Elem {
-	return NewSimpleTag("link", children...)
-}
-
-// ----------------------------------------------------
-// Empty Attribute
-// ----------------------------------------------------
-
-func Required() Elem {
-	return NewEmptyAttr("required")
-}
-
-func Disabled() Elem {
-	return NewEmptyAttr("disabled")
-}
-
 // ----------------------------------------------------
 // Helper Functions
 // ----------------------------------------------------
@@ -171,6 +143,8 @@ func NewNormalAttr(name string, value string) Elem {
 	}
 }
 
+// NewUnsafeAttr writes the value without escaping, it is an explicit opt-out
+// of NewNormalAttr and NewURLAttr, only used for trusted values
 func NewUnsafeAttr(name string, value string) Elem {
 	return Elem{
 		elemType: elemTypeAttribute,
@@ -0,0 +1,1039 @@
+// Code generated by hx/internal/gen; DO NOT EDIT.
+
+package hx
+
+// ----------------------------------------------------
+// Tags: Document and Metadata
+// ----------------------------------------------------
+
+func HtmlTag(children ...Elem) Elem {
+	return NewNormalTag("html", children...)
+}
+
+func Head(children ...Elem) Elem {
+	return NewNormalTag("head", children...)
+}
+
+func Body(children ...Elem) Elem {
+	return NewNormalTag("body", children...)
+}
+
+func Title(children ...Elem) Elem {
+	return NewNormalTag("title", children...)
+}
+
+func Style(children ...Elem) Elem {
+	return NewNormalTag("style", children...)
+}
+
+func Script(children ...Elem) Elem {
+	return NewNormalTag("script", children...)
+}
+
+func NoScript(children ...Elem) Elem {
+	return NewNormalTag("noscript", children...)
+}
+
+func Template(children ...Elem) Elem {
+	return NewNormalTag("template", children...)
+}
+
+func Base(children ...Elem) Elem {
+	return NewSimpleTag("base", children...)
+}
+
+func Link(children ...Elem) Elem {
+	return NewSimpleTag("link", children...)
+}
+
+func Meta(children ...Elem) Elem {
+	return NewSimpleTag("meta", children...)
+}
+
+// ----------------------------------------------------
+// Tags: Sections
+// ----------------------------------------------------
+
+func Header(children ...Elem) Elem {
+	return NewNormalTag("header", children...)
+}
+
+func Footer(children ...Elem) Elem {
+	return NewNormalTag("footer", children...)
+}
+
+func Main(children ...Elem) Elem {
+	return NewNormalTag("main", children...)
+}
+
+func Nav(children ...Elem) Elem {
+	return NewNormalTag("nav", children...)
+}
+
+func Section(children ...Elem) Elem {
+	return NewNormalTag("section", children...)
+}
+
+func Article(children ...Elem) Elem {
+	return NewNormalTag("article", children...)
+}
+
+func Aside(children ...Elem) Elem {
+	return NewNormalTag("aside", children...)
+}
+
+func Address(children ...Elem) Elem {
+	return NewNormalTag("address", children...)
+}
+
+func Search(children ...Elem) Elem {
+	return NewNormalTag("search", children...)
+}
+
+func H1(children ...Elem) Elem {
+	return NewNormalTag("h1", children...)
+}
+
+func H2(children ...Elem) Elem {
+	return NewNormalTag("h2", children...)
+}
+
+func H3(children ...Elem) Elem {
+	return NewNormalTag("h3", children...)
+}
+
+func H4(children ...Elem) Elem {
+	return NewNormalTag("h4", children...)
+}
+
+func H5(children ...Elem) Elem {
+	return NewNormalTag("h5", children...)
+}
+
+func H6(children ...Elem) Elem {
+	return NewNormalTag("h6", children...)
+}
+
+func HGroup(children ...Elem) Elem {
+	return NewNormalTag("hgroup", children...)
+}
+
+// ----------------------------------------------------
+// Tags: Grouping Content
+// ----------------------------------------------------
+
+func Div(children ...Elem) Elem {
+	return NewNormalTag("div", children...)
+}
+
+func P(children ...Elem) Elem {
+	return NewNormalTag("p", children...)
+}
+
+func Pre(children ...Elem) Elem {
+	return NewNormalTag("pre", children...)
+}
+
+func Blockquote(children ...Elem) Elem {
+	return NewNormalTag("blockquote", children...)
+}
+
+func Ul(children ...Elem) Elem {
+	return NewNormalTag("ul", children...)
+}
+
+func Ol(children ...Elem) Elem {
+	return NewNormalTag("ol", children...)
+}
+
+func Li(children ...Elem) Elem {
+	return NewNormalTag("li", children...)
+}
+
+func Menu(children ...Elem) Elem {
+	return NewNormalTag("menu", children...)
+}
+
+func Dl(children ...Elem) Elem {
+	return NewNormalTag("dl", children...)
+}
+
+func Dt(children ...Elem) Elem {
+	return NewNormalTag("dt", children...)
+}
+
+func Dd(children ...Elem) Elem {
+	return NewNormalTag("dd", children...)
+}
+
+func Figure(children ...Elem) Elem {
+	return NewNormalTag("figure", children...)
+}
+
+func FigCaption(children ...Elem) Elem {
+	return NewNormalTag("figcaption", children...)
+}
+
+func Hr(children ...Elem) Elem {
+	return NewSimpleTag("hr", children...)
+}
+
+// ----------------------------------------------------
+// Tags: Text Level
+// ----------------------------------------------------
+
+func A(children ...Elem) Elem {
+	return NewNormalTag("a", children...)
+}
+
+func Span(children ...Elem) Elem {
+	return NewNormalTag("span", children...)
+}
+
+func Em(children ...Elem) Elem {
+	return NewNormalTag("em", children...)
+}
+
+func Strong(children ...Elem) Elem {
+	return NewNormalTag("strong", children...)
+}
+
+func Small(children ...Elem) Elem {
+	return NewNormalTag("small", children...)
+}
+
+func S(children ...Elem) Elem {
+	return NewNormalTag("s", children...)
+}
+
+func Cite(children ...Elem) Elem {
+	return NewNormalTag("cite", children...)
+}
+
+func Q(children ...Elem) Elem {
+	return NewNormalTag("q", children...)
+}
+
+func Dfn(children ...Elem) Elem {
+	return NewNormalTag("dfn", children...)
+}
+
+func Abbr(children ...Elem) Elem {
+	return NewNormalTag("abbr", children...)
+}
+
+func Data(children ...Elem) Elem {
+	return NewNormalTag("data", children...)
+}
+
+func Time(children ...Elem) Elem {
+	return NewNormalTag("time", children...)
+}
+
+func Code(children ...Elem) Elem {
+	return NewNormalTag("code", children...)
+}
+
+func Var(children ...Elem) Elem {
+	return NewNormalTag("var", children...)
+}
+
+func Samp(children ...Elem) Elem {
+	return NewNormalTag("samp", children...)
+}
+
+func Kbd(children ...Elem) Elem {
+	return NewNormalTag("kbd", children...)
+}
+
+func Sub(children ...Elem) Elem {
+	return NewNormalTag("sub", children...)
+}
+
+func Sup(children ...Elem) Elem {
+	return NewNormalTag("sup", children...)
+}
+
+func I(children ...Elem) Elem {
+	return NewNormalTag("i", children...)
+}
+
+func B(children ...Elem) Elem {
+	return NewNormalTag("b", children...)
+}
+
+func U(children ...Elem) Elem {
+	return NewNormalTag("u", children...)
+}
+
+func Mark(children ...Elem) Elem {
+	return NewNormalTag("mark", children...)
+}
+
+func Bdi(children ...Elem) Elem {
+	return NewNormalTag("bdi", children...)
+}
+
+func Bdo(children ...Elem) Elem {
+	return NewNormalTag("bdo", children...)
+}
+
+func Ins(children ...Elem) Elem {
+	return NewNormalTag("ins", children...)
+}
+
+func Del(children ...Elem) Elem {
+	return NewNormalTag("del", children...)
+}
+
+func Br(children ...Elem) Elem {
+	return NewSimpleTag("br", children...)
+}
+
+func Wbr(children ...Elem) Elem {
+	return NewSimpleTag("wbr", children...)
+}
+
+// ----------------------------------------------------
+// Tags: Tables
+// ----------------------------------------------------
+
+func Table(children ...Elem) Elem {
+	return NewNormalTag("table", children...)
+}
+
+func Caption(children ...Elem) Elem {
+	return NewNormalTag("caption", children...)
+}
+
+func ColGroup(children ...Elem) Elem {
+	return NewNormalTag("colgroup", children...)
+}
+
+func THead(children ...Elem) Elem {
+	return NewNormalTag("thead", children...)
+}
+
+func TBody(children ...Elem) Elem {
+	return NewNormalTag("tbody", children...)
+}
+
+func TFoot(children ...Elem) Elem {
+	return NewNormalTag("tfoot", children...)
+}
+
+func Tr(children ...Elem) Elem {
+	return NewNormalTag("tr", children...)
+}
+
+func Th(children ...Elem) Elem {
+	return NewNormalTag("th", children...)
+}
+
+func Td(children ...Elem) Elem {
+	return NewNormalTag("td", children...)
+}
+
+func Col(children ...Elem) Elem {
+	return NewSimpleTag("col", children...)
+}
+
+// ----------------------------------------------------
+// Tags: Forms
+// ----------------------------------------------------
+
+func FormTag(children ...Elem) Elem {
+	return NewNormalTag("form", children...)
+}
+
+func Label(children ...Elem) Elem {
+	return NewNormalTag("label", children...)
+}
+
+func Button(children ...Elem) Elem {
+	return NewNormalTag("button", children...)
+}
+
+func Select(children ...Elem) Elem {
+	return NewNormalTag("select", children...)
+}
+
+func DataList(children ...Elem) Elem {
+	return NewNormalTag("datalist", children...)
+}
+
+func OptGroup(children ...Elem) Elem {
+	return NewNormalTag("optgroup", children...)
+}
+
+func Option(children ...Elem) Elem {
+	return NewNormalTag("option", children...)
+}
+
+func TextArea(children ...Elem) Elem {
+	return NewNormalTag("textarea", children...)
+}
+
+func Output(children ...Elem) Elem {
+	return NewNormalTag("output", children...)
+}
+
+func Progress(children ...Elem) Elem {
+	return NewNormalTag("progress", children...)
+}
+
+func Meter(children ...Elem) Elem {
+	return NewNormalTag("meter", children...)
+}
+
+func FieldSet(children ...Elem) Elem {
+	return NewNormalTag("fieldset", children...)
+}
+
+func Legend(children ...Elem) Elem {
+	return NewNormalTag("legend", children...)
+}
+
+func Input(children ...Elem) Elem {
+	return NewSimpleTag("input", children...)
+}
+
+// ----------------------------------------------------
+// Tags: Interactive
+// ----------------------------------------------------
+
+func Details(children ...Elem) Elem {
+	return NewNormalTag("details", children...)
+}
+
+func Summary(children ...Elem) Elem {
+	return NewNormalTag("summary", children...)
+}
+
+func Dialog(children ...Elem) Elem {
+	return NewNormalTag("dialog", children...)
+}
+
+// ----------------------------------------------------
+// Tags: Embedded Content and Media
+// ----------------------------------------------------
+
+func Picture(children ...Elem) Elem {
+	return NewNormalTag("picture", children...)
+}
+
+func IFrame(children ...Elem) Elem {
+	return NewNormalTag("iframe", children...)
+}
+
+func Object(children ...Elem) Elem {
+	return NewNormalTag("object", children...)
+}
+
+func Video(children ...Elem) Elem {
+	return NewNormalTag("video", children...)
+}
+
+func Audio(children ...Elem) Elem {
+	return NewNormalTag("audio", children...)
+}
+
+func Canvas(children ...Elem) Elem {
+	return NewNormalTag("canvas", children...)
+}
+
+func Map(children ...Elem) Elem {
+	return NewNormalTag("map", children...)
+}
+
+func Img(children ...Elem) Elem {
+	return NewSimpleTag("img", children...)
+}
+
+func Source(children ...Elem) Elem {
+	return NewSimpleTag("source", children...)
+}
+
+func Track(children ...Elem) Elem {
+	return NewSimpleTag("track", children...)
+}
+
+func Embed(children ...Elem) Elem {
+	return NewSimpleTag("embed", children...)
+}
+
+func Area(children ...Elem) Elem {
+	return NewSimpleTag("area", children...)
+}
+
+// ----------------------------------------------------
+// Tags: SVG
+// ----------------------------------------------------
+
+func Svg(children ...Elem) Elem {
+	return NewNormalTag("svg", children...)
+}
+
+func G(children ...Elem) Elem {
+	return NewNormalTag("g", children...)
+}
+
+func Defs(children ...Elem) Elem {
+	return NewNormalTag("defs", children...)
+}
+
+func Symbol(children ...Elem) Elem {
+	return NewNormalTag("symbol", children...)
+}
+
+func Use(children ...Elem) Elem {
+	return NewNormalTag("use", children...)
+}
+
+func SvgPath(children ...Elem) Elem {
+	return NewNormalTag("path", children...)
+}
+
+func Circle(children ...Elem) Elem {
+	return NewNormalTag("circle", children...)
+}
+
+func Ellipse(children ...Elem) Elem {
+	return NewNormalTag("ellipse", children...)
+}
+
+func Rect(children ...Elem) Elem {
+	return NewNormalTag("rect", children...)
+}
+
+func Line(children ...Elem) Elem {
+	return NewNormalTag("line", children...)
+}
+
+func Polyline(children ...Elem) Elem {
+	return NewNormalTag("polyline", children...)
+}
+
+func Polygon(children ...Elem) Elem {
+	return NewNormalTag("polygon", children...)
+}
+
+func SvgText(children ...Elem) Elem {
+	return NewNormalTag("text", children...)
+}
+
+func TSpan(children ...Elem) Elem {
+	return NewNormalTag("tspan", children...)
+}
+
+// ----------------------------------------------------
+// Attributes: Global Attributes
+// ----------------------------------------------------
+
+func TitleAttr(value string) Elem {
+	return NewNormalAttr("title", value)
+}
+
+func Lang(value string) Elem {
+	return NewNormalAttr("lang", value)
+}
+
+func Dir(value string) Elem {
+	return NewNormalAttr("dir", value)
+}
+
+func Role(value string) Elem {
+	return NewNormalAttr("role", value)
+}
+
+func TabIndex(value string) Elem {
+	return NewNormalAttr("tabindex", value)
+}
+
+func AccessKey(value string) Elem {
+	return NewNormalAttr("accesskey", value)
+}
+
+func ContentEditable(value string) Elem {
+	return NewNormalAttr("contenteditable", value)
+}
+
+func Draggable(value string) Elem {
+	return NewNormalAttr("draggable", value)
+}
+
+func SpellCheck(value string) Elem {
+	return NewNormalAttr("spellcheck", value)
+}
+
+func Translate(value string) Elem {
+	return NewNormalAttr("translate", value)
+}
+
+func EnterKeyHint(value string) Elem {
+	return NewNormalAttr("enterkeyhint", value)
+}
+
+func InputMode(value string) Elem {
+	return NewNormalAttr("inputmode", value)
+}
+
+func Popover(value string) Elem {
+	return NewNormalAttr("popover", value)
+}
+
+func SlotAttr(value string) Elem {
+	return NewNormalAttr("slot", value)
+}
+
+func Hidden() Elem {
+	return NewEmptyAttr("hidden")
+}
+
+func Inert() Elem {
+	return NewEmptyAttr("inert")
+}
+
+func AutoFocus() Elem {
+	return NewEmptyAttr("autofocus")
+}
+
+// ----------------------------------------------------
+// Attributes: Links and Metadata
+// ----------------------------------------------------
+
+func Action(urlPath string) Elem {
+	return NewURLAttr("action", urlPath)
+}
+
+func FormAction(urlPath string) Elem {
+	return NewURLAttr("formaction", urlPath)
+}
+
+func Poster(urlPath string) Elem {
+	return NewURLAttr("poster", urlPath)
+}
+
+func CiteAttr(urlPath string) Elem {
+	return NewURLAttr("cite", urlPath)
+}
+
+func Target(value string) Elem {
+	return NewNormalAttr("target", value)
+}
+
+func Download(value string) Elem {
+	return NewNormalAttr("download", value)
+}
+
+func HrefLang(value string) Elem {
+	return NewNormalAttr("hreflang", value)
+}
+
+func ReferrerPolicy(value string) Elem {
+	return NewNormalAttr("referrerpolicy", value)
+}
+
+func CrossOrigin(value string) Elem {
+	return NewNormalAttr("crossorigin", value)
+}
+
+func Integrity(value string) Elem {
+	return NewNormalAttr("integrity", value)
+}
+
+func Media(value string) Elem {
+	return NewNormalAttr("media", value)
+}
+
+func Sizes(value string) Elem {
+	return NewNormalAttr("sizes", value)
+}
+
+func SrcSet(value string) Elem {
+	return NewNormalAttr("srcset", value)
+}
+
+func Charset(value string) Elem {
+	return NewNormalAttr("charset", value)
+}
+
+func Content(value string) Elem {
+	return NewNormalAttr("content", value)
+}
+
+func HttpEquiv(value string) Elem {
+	return NewNormalAttr("http-equiv", value)
+}
+
+func As(value string) Elem {
+	return NewNormalAttr("as", value)
+}
+
+func Nonce(value string) Elem {
+	return NewNormalAttr("nonce", value)
+}
+
+func Async() Elem {
+	return NewEmptyAttr("async")
+}
+
+func Defer() Elem {
+	return NewEmptyAttr("defer")
+}
+
+func NoModule() Elem {
+	return NewEmptyAttr("nomodule")
+}
+
+// ----------------------------------------------------
+// Attributes: Forms
+// ----------------------------------------------------
+
+func Type(value string) Elem {
+	return NewNormalAttr("type", value)
+}
+
+func Value(value string) Elem {
+	return NewNormalAttr("value", value)
+}
+
+func Placeholder(value string) Elem {
+	return NewNormalAttr("placeholder", value)
+}
+
+func For(value string) Elem {
+	return NewNormalAttr("for", value)
+}
+
+func FormAttr(value string) Elem {
+	return NewNormalAttr("form", value)
+}
+
+func Method(value string) Elem {
+	return NewNormalAttr("method", value)
+}
+
+func EncType(value string) Elem {
+	return NewNormalAttr("enctype", value)
+}
+
+func AutoComplete(value string) Elem {
+	return NewNormalAttr("autocomplete", value)
+}
+
+func Accept(value string) Elem {
+	return NewNormalAttr("accept", value)
+}
+
+func AcceptCharset(value string) Elem {
+	return NewNormalAttr("accept-charset", value)
+}
+
+func Min(value string) Elem {
+	return NewNormalAttr("min", value)
+}
+
+func Max(value string) Elem {
+	return NewNormalAttr("max", value)
+}
+
+func Step(value string) Elem {
+	return NewNormalAttr("step", value)
+}
+
+func MinLength(value string) Elem {
+	return NewNormalAttr("minlength", value)
+}
+
+func MaxLength(value string) Elem {
+	return NewNormalAttr("maxlength", value)
+}
+
+func Pattern(value string) Elem {
+	return NewNormalAttr("pattern", value)
+}
+
+func Size(value string) Elem {
+	return NewNormalAttr("size", value)
+}
+
+func Rows(value string) Elem {
+	return NewNormalAttr("rows", value)
+}
+
+func Cols(value string) Elem {
+	return NewNormalAttr("cols", value)
+}
+
+func Wrap(value string) Elem {
+	return NewNormalAttr("wrap", value)
+}
+
+func List(value string) Elem {
+	return NewNormalAttr("list", value)
+}
+
+func LabelAttr(value string) Elem {
+	return NewNormalAttr("label", value)
+}
+
+func High(value string) Elem {
+	return NewNormalAttr("high", value)
+}
+
+func Low(value string) Elem {
+	return NewNormalAttr("low", value)
+}
+
+func Optimum(value string) Elem {
+	return NewNormalAttr("optimum", value)
+}
+
+func Required() Elem {
+	return NewEmptyAttr("required")
+}
+
+func Disabled() Elem {
+	return NewEmptyAttr("disabled")
+}
+
+func Checked() Elem {
+	return NewEmptyAttr("checked")
+}
+
+func Selected() Elem {
+	return NewEmptyAttr("selected")
+}
+
+func Multiple() Elem {
+	return NewEmptyAttr("multiple")
+}
+
+func ReadOnly() Elem {
+	return NewEmptyAttr("readonly")
+}
+
+func NoValidate() Elem {
+	return NewEmptyAttr("novalidate")
+}
+
+func FormNoValidate() Elem {
+	return NewEmptyAttr("formnovalidate")
+}
+
+// ----------------------------------------------------
+// Attributes: Tables
+// ----------------------------------------------------
+
+func ColSpan(value string) Elem {
+	return NewNormalAttr("colspan", value)
+}
+
+func RowSpan(value string) Elem {
+	return NewNormalAttr("rowspan", value)
+}
+
+func SpanAttr(value string) Elem {
+	return NewNormalAttr("span", value)
+}
+
+func Headers(value string) Elem {
+	return NewNormalAttr("headers", value)
+}
+
+func Scope(value string) Elem {
+	return NewNormalAttr("scope", value)
+}
+
+func AbbrAttr(value string) Elem {
+	return NewNormalAttr("abbr", value)
+}
+
+// ----------------------------------------------------
+// Attributes: Embedded Content and Media
+// ----------------------------------------------------
+
+func Alt(value string) Elem {
+	return NewNormalAttr("alt", value)
+}
+
+func Width(value string) Elem {
+	return NewNormalAttr("width", value)
+}
+
+func Height(value string) Elem {
+	return NewNormalAttr("height", value)
+}
+
+func Loading(value string) Elem {
+	return NewNormalAttr("loading", value)
+}
+
+func Decoding(value string) Elem {
+	return NewNormalAttr("decoding", value)
+}
+
+func Preload(value string) Elem {
+	return NewNormalAttr("preload", value)
+}
+
+func Kind(value string) Elem {
+	return NewNormalAttr("kind", value)
+}
+
+func SrcLang(value string) Elem {
+	return NewNormalAttr("srclang", value)
+}
+
+func Sandbox(value string) Elem {
+	return NewNormalAttr("sandbox", value)
+}
+
+func Allow(value string) Elem {
+	return NewNormalAttr("allow", value)
+}
+
+func Coords(value string) Elem {
+	return NewNormalAttr("coords", value)
+}
+
+func Shape(value string) Elem {
+	return NewNormalAttr("shape", value)
+}
+
+func UseMap(value string) Elem {
+	return NewNormalAttr("usemap", value)
+}
+
+func DateTime(value string) Elem {
+	return NewNormalAttr("datetime", value)
+}
+
+func Controls() Elem {
+	return NewEmptyAttr("controls")
+}
+
+func AutoPlay() Elem {
+	return NewEmptyAttr("autoplay")
+}
+
+func Loop() Elem {
+	return NewEmptyAttr("loop")
+}
+
+func Muted() Elem {
+	return NewEmptyAttr("muted")
+}
+
+func PlaysInline() Elem {
+	return NewEmptyAttr("playsinline")
+}
+
+func Default() Elem {
+	return NewEmptyAttr("default")
+}
+
+func Open() Elem {
+	return NewEmptyAttr("open")
+}
+
+func IsMap() Elem {
+	return NewEmptyAttr("ismap")
+}
+
+func Reversed() Elem {
+	return NewEmptyAttr("reversed")
+}
+
+// ----------------------------------------------------
+// Attributes: SVG Attributes
+// ----------------------------------------------------
+
+func Xmlns(value string) Elem {
+	return NewNormalAttr("xmlns", value)
+}
+
+func ViewBox(value string) Elem {
+	return NewNormalAttr("viewBox", value)
+}
+
+func Fill(value string) Elem {
+	return NewNormalAttr("fill", value)
+}
+
+func FillRule(value string) Elem {
+	return NewNormalAttr("fill-rule", value)
+}
+
+func Stroke(value string) Elem {
+	return NewNormalAttr("stroke", value)
+}
+
+func StrokeWidth(value string) Elem {
+	return NewNormalAttr("stroke-width", value)
+}
+
+func StrokeLinecap(value string) Elem {
+	return NewNormalAttr("stroke-linecap", value)
+}
+
+func StrokeLinejoin(value string) Elem {
+	return NewNormalAttr("stroke-linejoin", value)
+}
+
+func Transform(value string) Elem {
+	return NewNormalAttr("transform", value)
+}
+
+func D(value string) Elem {
+	return NewNormalAttr("d", value)
+}
+
+func Points(value string) Elem {
+	return NewNormalAttr("points", value)
+}
+
+func Cx(value string) Elem {
+	return NewNormalAttr("cx", value)
+}
+
+func Cy(value string) Elem {
+	return NewNormalAttr("cy", value)
+}
+
+func R(value string) Elem {
+	return NewNormalAttr("r", value)
+}
+
+func Rx(value string) Elem {
+	return NewNormalAttr("rx", value)
+}
+
+func Ry(value string) Elem {
+	return NewNormalAttr("ry", value)
+}
+
+func X(value string) Elem {
+	return NewNormalAttr("x", value)
+}
+
+func Y(value string) Elem {
+	return NewNormalAttr("y", value)
+}
+
+func X1(value string) Elem {
+	return NewNormalAttr("x1", value)
+}
+
+func Y1(value string) Elem {
+	return NewNormalAttr("y1", value)
+}
+
+func X2(value string) Elem {
+	return NewNormalAttr("x2", value)
+}
+
+func Y2(value string) Elem {
+	return NewNormalAttr("y2", value)
+}
@@ -0,0 +1,167 @@
+package hx
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ----------------------------------------------------
+// URL
+// ----------------------------------------------------
+
+// SafeURL is a url with an allowed scheme, created by SanitizeURL
+type SafeURL string
+
+// UnsafeURLReplacement replaces urls with schemes not in the allow list
+const UnsafeURLReplacement SafeURL = "about:invalid#hx-unsafe-url"
+
+var allowedURLSchemes = []string{"http", "https", "mailto", "tel"}
+
+// SanitizeURL allows relative urls and urls with schemes: http, https, mailto and tel.
+// Other urls, e.g. javascript:alert(1), are replaced by UnsafeURLReplacement
+func SanitizeURL(rawURL string) SafeURL {
+	trimmed := strings.TrimSpace(rawURL)
+
+	scheme, ok := getURLScheme(trimmed)
+	if !ok {
+		return SafeURL(trimmed)
+	}
+
+	for _, allowed := range allowedURLSchemes {
+		if strings.EqualFold(scheme, allowed) {
+			return SafeURL(trimmed)
+		}
+	}
+	return UnsafeURLReplacement
+}
+
+// NewURLAttr creates an attribute with the url sanitized by SanitizeURL and escaped
+func NewURLAttr(name string, rawURL string) Elem {
+	return NewNormalAttr(name, string(SanitizeURL(rawURL)))
+}
+
+// ----------------------------------------------------
+// Script
+// ----------------------------------------------------
+
+// ScriptCode is javascript code inside the <script> element, it is not html escaped.
+// Sequences closing the script element, like </script, are neutralized
+func ScriptCode(code string) Elem {
+	return Elem{
+		elemType: elemTypeContent,
+		value:    []byte(escapeScriptContent(code)),
+	}
+}
+
+// JSONValue encodes the value as json for embedding in the <script> element,
+// characters <, > and & are escaped as unicode sequences. It panics if the value can not be encoded
+func JSONValue(value any) Elem {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode json value: %v", err))
+	}
+	return Elem{
+		elemType: elemTypeContent,
+		value:    data,
+	}
+}
+
+// ScriptJSON renders <script type="application/json"> containing the value encoded as json,
+// it can be read by JSON.parse(document.getElementById(id).textContent)
+func ScriptJSON(id ElemID, value any) Elem {
+	return Script(
+		Type("application/json"),
+		ID(id),
+		JSONValue(value),
+	)
+}
+
+// ----------------------------------------------------
+// Style
+// ----------------------------------------------------
+
+// UnsafeCSSReplacement replaces unsafe css values
+const UnsafeCSSReplacement = "hx-unsafe-css"
+
+// StyleAttr creates the style attribute with declarations sanitized by SanitizeCSSValue,
+// declarations with invalid property names are removed
+func StyleAttr(style string) Elem {
+	var buf strings.Builder
+	for declaration := range strings.SplitSeq(style, ";") {
+		property, value, found := strings.Cut(declaration, ":")
+		property = strings.TrimSpace(property)
+		value = strings.TrimSpace(value)
+		if !found || !cssPropertyRegexp.MatchString(property) {
+			continue
+		}
+
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(property)
+		buf.WriteString(": ")
+		buf.WriteString(SanitizeCSSValue(value))
+		buf.WriteString(";")
+	}
+	return NewNormalAttr("style", buf.String())
+}
+
+// SanitizeCSSValue returns UnsafeCSSReplacement if the value contains characters that can escape
+// the declaration, or constructs that can load resources or run code, e.g. url(), expression()
+func SanitizeCSSValue(value string) string {
+	if strings.ContainsAny(value, "<>\"'`;{}\\") {
+		return UnsafeCSSReplacement
+	}
+
+	lower := strings.ToLower(value)
+	for _, keyword := range unsafeCSSKeywords {
+		if strings.Contains(lower, keyword) {
+			return UnsafeCSSReplacement
+		}
+	}
+	return value
+}
+
+// ---------------------------------------------------------------------------
+// Internal Implementation
+// ---------------------------------------------------------------------------
+
+var cssPropertyRegexp = regexp.MustCompile(`^-{0,2}[a-zA-Z][a-zA-Z0-9-]*$`)
+
+var unsafeCSSKeywords = []string{
+	"url(", "expression(", "image-set(", "@import", "javascript:", "behavior:", "-moz-binding", "/*",
+}
+
+// getURLScheme returns the scheme of the url, ok = false for relative urls
+func getURLScheme(rawURL string) (string, bool) {
+	for i := range len(rawURL) {
+		c := rawURL[i]
+		switch {
+		case c == ':':
+			return rawURL[:i], i > 0
+		case c == '/' || c == '?' || c == '#':
+			return "", false
+		case isSchemeChar(c):
+			continue
+		default:
+			// invalid characters, e.g. control characters in "java\tscript:", are considered unsafe
+			return rawURL[:i], strings.Contains(rawURL[i:], ":")
+		}
+	}
+	return "", false
+}
+
+func isSchemeChar(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') ||
+		('0' <= c && c <= '9') || c == '+' || c == '-' || c == '.'
+}
+
+var scriptCloseRegexp = regexp.MustCompile(`(?i)<(/script|!--)`)
+
+func escapeScriptContent(code string) string {
+	return scriptCloseRegexp.ReplaceAllStringFunc(code, func(s string) string {
+		return `<\` + s[1:]
+	})
+}
@@ -0,0 +1,120 @@
+package hx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeURL(t *testing.T) {
+	assert.Equal(t, SafeURL("/users/11?a=1&b=2"), SanitizeURL("/users/11?a=1&b=2"))
+	assert.Equal(t, SafeURL("users/11"), SanitizeURL("users/11"))
+	assert.Equal(t, SafeURL("#top"), SanitizeURL("#top"))
+	assert.Equal(t, SafeURL("//cdn.example.com/a.js"), SanitizeURL("//cdn.example.com/a.js"))
+	assert.Equal(t, SafeURL("https://example.com/a:b"), SanitizeURL(" https://example.com/a:b "))
+	assert.Equal(t, SafeURL("HTTP://example.com"), SanitizeURL("HTTP://example.com"))
+	assert.Equal(t, SafeURL("mailto:user@example.com"), SanitizeURL("mailto:user@example.com"))
+	assert.Equal(t, SafeURL("tel:+84123"), SanitizeURL("tel:+84123"))
+	assert.Equal(t, SafeURL("/search?q=a:b"), SanitizeURL("/search?q=a:b"))
+
+	assert.Equal(t, UnsafeURLReplacement, SanitizeURL("javascript:alert(1)"))
+	assert.Equal(t, UnsafeURLReplacement, SanitizeURL(" JavaScript:alert(1)"))
+	assert.Equal(t, UnsafeURLReplacement, SanitizeURL("java\tscript:alert(1)"))
+	assert.Equal(t, UnsafeURLReplacement, SanitizeURL("\x00javascript:alert(1)"))
+	assert.Equal(t, UnsafeURLReplacement, SanitizeURL("data:text/html;base64,PHNjcmlwdD4="))
+	assert.Equal(t, UnsafeURLReplacement, SanitizeURL("vbscript:msgbox"))
+}
+
+func TestURLAttributes(t *testing.T) {
+	t.Run("escaped", func(t *testing.T) {
+		elem := A(Href(`/search?q="><script>&x=1`), Text("Search"))
+		assertSimpleContent(t,
+			`<a href="/search?q=&#34;&gt;&lt;script&gt;&amp;x=1">Search</a>`,
+			elem,
+		)
+	})
+
+	t.Run("unsafe scheme", func(t *testing.T) {
+		elem := Group(
+			A(Href("javascript:alert(1)")),
+			Img(Src("data:image/svg+xml,abc")),
+			FormTag(Action("javascript:void(0)")),
+		)
+		assertSimpleContent(t,
+			`<a href="about:invalid#hx-unsafe-url"></a>`+
+				`<img src="about:invalid#hx-unsafe-url">`+
+				`<form action="about:invalid#hx-unsafe-url"></form>`,
+			elem,
+		)
+	})
+
+	t.Run("unsafe attr as opt-out", func(t *testing.T) {
+		elem := A(NewUnsafeAttr("href", "javascript:void(0)"))
+		assertSimpleContent(t, `<a href="javascript:void(0)"></a>`, elem)
+	})
+}
+
+func TestScript(t *testing.T) {
+	t.Run("script code", func(t *testing.T) {
+		elem := Script(ScriptCode(`if (a < b && c > d) { s = "</script><script>alert(1)</SCRIPT>"; } <!-- x`))
+		assertSimpleContent(t,
+			`<script>if (a < b && c > d) { s = "<\/script><script>alert(1)<\/SCRIPT>"; } <\!-- x</script>`,
+			elem,
+		)
+	})
+
+	t.Run("json value", func(t *testing.T) {
+		elem := Script(
+			ScriptCode("const user = "),
+			JSONValue(map[string]any{"name": "</script>&'", "id": 11}),
+			ScriptCode(";"),
+		)
+		assertSimpleContent(t,
+			`<script>const user = {"id":11,"name":"\u003c/script\u003e\u0026'"};</script>`,
+			elem,
+		)
+	})
+
+	t.Run("script json", func(t *testing.T) {
+		elem := ScriptJSON("user-data", []string{"a<b"})
+		assertSimpleContent(t,
+			`<script type="application/json" id="user-data">["a\u003cb"]</script>`,
+			elem,
+		)
+	})
+
+	t.Run("json value can not be encoded", func(t *testing.T) {
+		assert.PanicsWithValue(t, "failed to encode json value: json: unsupported type: func()", func() {
+			JSONValue(func() {})
+		})
+	})
+}
+
+func TestStyle(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		elem := Div(StyleAttr("color: red; margin:0 auto;--main-bg: #fff;"))
+		assertSimpleContent(t, `<div style="color: red; margin: 0 auto; --main-bg: #fff;"></div>`, elem)
+	})
+
+	t.Run("unsafe values", func(t *testing.T) {
+		elem := Div(StyleAttr(`background: url(javascript:alert(1)); width: expression(alert(1)); color: red"`))
+		assertSimpleContent(t,
+			`<div style="background: hx-unsafe-css; width: hx-unsafe-css; color: hx-unsafe-css;"></div>`,
+			elem,
+		)
+	})
+
+	t.Run("invalid property names are removed", func(t *testing.T) {
+		elem := Div(StyleAttr(`color: red; x}body{color: blue; no-value`))
+		assertSimpleContent(t, `<div style="color: red;"></div>`, elem)
+	})
+
+	t.Run("sanitize css value", func(t *testing.T) {
+		assert.Equal(t, "1px solid #ccc", SanitizeCSSValue("1px solid #ccc"))
+		assert.Equal(t, "calc(100% - 2px)", SanitizeCSSValue("calc(100% - 2px)"))
+		assert.Equal(t, UnsafeCSSReplacement, SanitizeCSSValue("red; background: blue"))
+		assert.Equal(t, UnsafeCSSReplacement, SanitizeCSSValue("URL(a.png)"))
+		assert.Equal(t, UnsafeCSSReplacement, SanitizeCSSValue(`\75rl(a.png)`))
+		assert.Equal(t, UnsafeCSSReplacement, SanitizeCSSValue("red /* comment */"))
+	})
+}
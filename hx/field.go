@@ -0,0 +1,29 @@
+package hx
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+// FieldName returns the input name of the field selected by the selector.
+// It panics if the field is not a non path param of the url path.
+// The returned name can be used with methods of Form, e.g. form.Input(FieldName(...))
+func FieldName[T any, F any](urlPath urls.Path[T], selector func(p *T) *F) string {
+	name := urls.FieldName(selector)
+	if !slices.Contains(urlPath.GetNonPathParams(), name) {
+		panic(fmt.Sprintf("'%s' is not a param of url path '%s'", name, urlPath.GetPattern()))
+	}
+	return name
+}
+
+// FieldInput renders an input with the name derived from the selected field, e.g.
+//
+//	hx.FieldInput(path, func(p *UserParams) *string { return &p.Name }, hx.Required())
+func FieldInput[T any, F any](urlPath urls.Path[T], selector func(p *T) *F, attrs ...Elem) Elem {
+	return Input(
+		Name(FieldName(urlPath, selector)),
+		Group(attrs...),
+	)
+}
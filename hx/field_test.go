@@ -0,0 +1,33 @@
+package hx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func TestFieldInput(t *testing.T) {
+	urlPath := urls.New[formParams]("/users/{id}")
+
+	t.Run("normal", func(t *testing.T) {
+		elem := StrictForm(urlPath,
+			FieldInput(urlPath, func(p *formParams) *string { return &p.Name }, Required()),
+			FieldInput(urlPath, func(p *formParams) *[]string { return &p.Tags }),
+		)
+		assertSimpleContent(t, `<form><input name="name" required><input name="tag"></form>`, elem)
+	})
+
+	t.Run("with form", func(t *testing.T) {
+		f := NewForm(urlPath, formParams{Name: "user01"}, nil)
+		elem := f.Input(FieldName(urlPath, func(p *formParams) *string { return &p.Name }))
+		assertSimpleContent(t, `<input name="name" value="user01">`, elem)
+	})
+
+	t.Run("path param", func(t *testing.T) {
+		assert.PanicsWithValue(t, "'id' is not a param of url path '/users/{id}'", func() {
+			FieldInput(urlPath, func(p *formParams) *int { return &p.ID })
+		})
+	})
+}
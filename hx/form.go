@@ -0,0 +1,222 @@
+package hx
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+// Form is a typed form builder, inputs are filled with current values of params
+// and rendered with their error messages.
+// Input names must be non path params of the url path, otherwise the builder panics
+type Form[T any] struct {
+	urlPath     urls.Path[T]
+	paramSet    map[string]struct{}
+	values      url.Values
+	fieldErrors map[string]string
+}
+
+// NewForm creates a form builder, fieldErrors is a map from param name to error message,
+// e.g. from urls.ValidationError.ToMap().
+// Zero values are empty, except for params with errors, so that e.g. age=0 failing min=1 is kept
+func NewForm[T any](urlPath urls.Path[T], params T, fieldErrors map[string]string) *Form[T] {
+	paramSet := map[string]struct{}{}
+	for _, param := range urlPath.GetNonPathParams() {
+		paramSet[param] = struct{}{}
+	}
+
+	values := urlPath.GetNonPathValues(params)
+	if len(fieldErrors) > 0 {
+		valuesWithZero := urlPath.GetNonPathValuesWithZero(params)
+		for name := range fieldErrors {
+			if _, ok := values[name]; ok {
+				continue
+			}
+			if zeroValues, ok := valuesWithZero[name]; ok {
+				values[name] = zeroValues
+			}
+		}
+	}
+
+	return &Form[T]{
+		urlPath:     urlPath,
+		paramSet:    paramSet,
+		values:      values,
+		fieldErrors: fieldErrors,
+	}
+}
+
+// WithSubmittedValues replaces the current values by the submitted values of the request, e.g. req.Form.
+// So that values which can not be bound to params (e.g. 'abc' for an int param) are still shown to the user.
+// Only params existing in the submitted values are replaced
+func (f *Form[T]) WithSubmittedValues(submitted url.Values) *Form[T] {
+	newValues := url.Values{}
+	for name, values := range f.values {
+		newValues[name] = values
+	}
+	for name := range f.paramSet {
+		if values, ok := submitted[name]; ok {
+			newValues[name] = values
+		}
+	}
+
+	newForm := *f
+	newForm.values = newValues
+	return &newForm
+}
+
+// Render renders the form element using StrictForm
+func (f *Form[T]) Render(children ...Elem) Elem {
+	return StrictForm(f.urlPath, children...)
+}
+
+// Value returns the current value of the param
+func (f *Form[T]) Value(name string) string {
+	f.checkName(name)
+	return f.values.Get(name)
+}
+
+// Error returns the error message of the param
+func (f *Form[T]) Error(name string) (string, bool) {
+	f.checkName(name)
+	msg, ok := f.fieldErrors[name]
+	return msg, ok
+}
+
+// Input renders an input with the current value, followed by the error message if any.
+// The value is not rendered for password and file inputs, the type is taken from attrs
+func (f *Form[T]) Input(name string, attrs ...Elem) Elem {
+	value := f.Value(name)
+
+	valueAttr := None()
+	if len(value) > 0 && !isNoValueInputType(attrs) {
+		valueAttr = Value(value)
+	}
+
+	return Group(
+		Input(
+			Name(name),
+			valueAttr,
+			f.invalidAttrs(name),
+			Group(attrs...),
+		),
+		f.FieldError(name),
+	)
+}
+
+// TextArea renders a textarea with the current value, followed by the error message if any
+func (f *Form[T]) TextArea(name string, attrs ...Elem) Elem {
+	return Group(
+		TextArea(
+			Name(name),
+			f.invalidAttrs(name),
+			Group(attrs...),
+			Text(f.Value(name)),
+		),
+		f.FieldError(name),
+	)
+}
+
+// SelectOption is an option of Form.Select
+type SelectOption struct {
+	Value string
+	Label string
+}
+
+// Select renders a select, options matching the current values are selected
+func (f *Form[T]) Select(name string, options []SelectOption, attrs ...Elem) Elem {
+	f.checkName(name)
+	currentValues := f.values[name]
+
+	optionElems := make([]Elem, 0, len(options))
+	for _, option := range options {
+		selectedAttr := None()
+		if slices.Contains(currentValues, option.Value) {
+			selectedAttr = Selected()
+		}
+		optionElems = append(optionElems, Option(
+			Value(option.Value),
+			selectedAttr,
+			Text(option.Label),
+		))
+	}
+
+	return Group(
+		Select(
+			Name(name),
+			f.invalidAttrs(name),
+			Group(attrs...),
+			Group(optionElems...),
+		),
+		f.FieldError(name),
+	)
+}
+
+// Checkbox renders a checkbox input, it is checked if the value is one of the current values
+func (f *Form[T]) Checkbox(name string, value string, attrs ...Elem) Elem {
+	f.checkName(name)
+
+	checkedAttr := None()
+	if slices.Contains(f.values[name], value) {
+		checkedAttr = Checked()
+	}
+
+	return Input(
+		Type("checkbox"),
+		Name(name),
+		Value(value),
+		checkedAttr,
+		f.invalidAttrs(name),
+		Group(attrs...),
+	)
+}
+
+// FieldError renders the error message of the param, with id referenced by aria-describedby of inputs
+func (f *Form[T]) FieldError(name string) Elem {
+	msg, ok := f.Error(name)
+	if !ok {
+		return None()
+	}
+	return P(
+		ID(f.errorID(name)),
+		Class("field-error"),
+		Text(msg),
+	)
+}
+
+func (f *Form[T]) invalidAttrs(name string) Elem {
+	if _, ok := f.fieldErrors[name]; !ok {
+		return None()
+	}
+	return Group(
+		AriaAttr("invalid", "true"),
+		AriaAttr("describedby", string(f.errorID(name))),
+	)
+}
+
+func (f *Form[T]) errorID(name string) ElemID {
+	return ElemID(name + "-error")
+}
+
+func (f *Form[T]) checkName(name string) {
+	if _, ok := f.paramSet[name]; ok {
+		return
+	}
+	panic(fmt.Sprintf("'%s' is not a param of url path '%s'", name, f.urlPath.GetPattern()))
+}
+
+// isNoValueInputType checks whether the type attribute is password or file,
+// values of these inputs should not be sent back to the client
+func isNoValueInputType(attrs []Elem) bool {
+	for _, attr := range flattenAttrs(attrs) {
+		if string(attr.name) != "type" {
+			continue
+		}
+		inputType := strings.ToLower(string(attr.value))
+		return inputType == "password" || inputType == "file"
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package hx
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+type formParams struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name"`
+	Password string   `json:"password"`
+	Age      int      `json:"age"`
+	Bio      string   `json:"bio"`
+	Role     string   `json:"role"`
+	Tags     []string `json:"tag"`
+	Active   bool     `json:"active"`
+}
+
+func TestForm(t *testing.T) {
+	urlPath := urls.New[formParams]("/users/{id}")
+
+	params := formParams{
+		ID:     11,
+		Name:   "user01",
+		Bio:    "<b>hello</b>",
+		Role:   "member",
+		Tags:   []string{"a", "c"},
+		Active: true,
+	}
+
+	t.Run("without errors", func(t *testing.T) {
+		f := NewForm(urlPath, params, nil)
+
+		assertSimpleContent(t,
+			`<form>`+
+				`<input name="name" value="user01">`+
+				`<textarea name="bio">&lt;b&gt;hello&lt;/b&gt;</textarea>`+
+				`<select name="role"><option value="admin">Admin</option><option value="member" selected>Member</option></select>`+
+				`<input type="checkbox" name="tag" value="a" checked>`+
+				`<input type="checkbox" name="tag" value="b">`+
+				`<input type="checkbox" name="active" value="true" checked>`+
+				`</form>`,
+			f.Render(
+				f.Input("name"),
+				f.TextArea("bio"),
+				f.Select("role", []SelectOption{
+					{Value: "admin", Label: "Admin"},
+					{Value: "member", Label: "Member"},
+				}),
+				f.Checkbox("tag", "a"),
+				f.Checkbox("tag", "b"),
+				f.Checkbox("active", "true"),
+			),
+		)
+	})
+
+	t.Run("with errors", func(t *testing.T) {
+		f := NewForm(urlPath, formParams{ID: 11}, map[string]string{
+			"name": "is required",
+			"role": "must be one of: admin, member",
+		})
+
+		assertSimpleContent(t,
+			`<form>`+
+				`<input name="name" aria-invalid="true" aria-describedby="name-error" class="input">`+
+				`<p id="name-error" class="field-error">is required</p>`+
+				`<select name="role" aria-invalid="true" aria-describedby="role-error"></select>`+
+				`<p id="role-error" class="field-error">must be one of: admin, member</p>`+
+				`<input name="bio">`+
+				`</form>`,
+			f.Render(
+				f.Input("name", Class("input")),
+				f.Select("role", nil),
+				f.Input("bio"),
+			),
+		)
+
+		msg, ok := f.Error("name")
+		assert.Equal(t, true, ok)
+		assert.Equal(t, "is required", msg)
+
+		_, ok = f.Error("bio")
+		assert.Equal(t, false, ok)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		f := NewForm(urlPath, params, nil)
+		assert.Equal(t, "user01", f.Value("name"))
+		assert.Equal(t, "a", f.Value("tag"))
+		assert.Equal(t, "true", f.Value("active"))
+
+		// zero values are empty
+		f = NewForm(urlPath, formParams{}, nil)
+		assert.Equal(t, "", f.Value("name"))
+	})
+
+	t.Run("password input without value", func(t *testing.T) {
+		f := NewForm(urlPath, formParams{ID: 11, Name: "user01", Password: "secret"}, nil)
+
+		assertSimpleContent(t,
+			`<form>`+
+				`<input name="password" type="password">`+
+				`<input name="password" class="input" type="PASSWORD">`+
+				`<input name="name" value="user01" type="text">`+
+				`</form>`,
+			f.Render(
+				f.Input("password", Type("password")),
+				f.Input("password", Group(Class("input"), Type("PASSWORD"))),
+				f.Input("name", Type("text")),
+			),
+		)
+	})
+
+	t.Run("zero value with error", func(t *testing.T) {
+		f := NewForm(urlPath, formParams{ID: 11, Age: 0}, map[string]string{
+			"age": "must be at least 1",
+		})
+
+		assertSimpleContent(t,
+			`<form>`+
+				`<input name="age" value="0" aria-invalid="true" aria-describedby="age-error">`+
+				`<p id="age-error" class="field-error">must be at least 1</p>`+
+				`<input name="name">`+
+				`</form>`,
+			f.Render(
+				f.Input("age"),
+				f.Input("name"),
+			),
+		)
+	})
+
+	t.Run("with submitted values", func(t *testing.T) {
+		f := NewForm(urlPath, formParams{ID: 11, Name: "user01", Role: "admin"}, map[string]string{
+			"age": "invalid number",
+		}).WithSubmittedValues(url.Values{
+			"age":  {"abc"},
+			"role": {"member"},
+			"id":   {"12"},
+		})
+
+		assert.Equal(t, "abc", f.Value("age"))
+		assert.Equal(t, "member", f.Value("role"))
+		assert.Equal(t, "user01", f.Value("name"))
+	})
+
+	t.Run("path param or unknown name", func(t *testing.T) {
+		f := NewForm(urlPath, params, nil)
+		assert.PanicsWithValue(t, "'id' is not a param of url path '/users/{id}'", func() {
+			f.Input("id")
+		})
+		assert.PanicsWithValue(t, "'unknown' is not a param of url path '/users/{id}'", func() {
+			f.Value("unknown")
+		})
+	})
+}
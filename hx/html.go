@@ -26,23 +26,22 @@ func Html(
 	}
 
 	headList := []Elem{
-		NewNormalTag("title", Text(title)),
-		NewSimpleTag("meta",
-			NewNormalAttr("charset", "UTF-8"),
+		Title(Text(title)),
+		Meta(
+			Charset("UTF-8"),
 		),
-		NewSimpleTag("meta",
-			NewNormalAttr("name", "viewport"),
-			NewNormalAttr("content", "width=device-width, initial-scale=1.0"),
+		Meta(
+			Name("viewport"),
+			Content("width=device-width, initial-scale=1.0"),
 		),
 		head,
 	}
 
-	htmlContent := NewNormalTag(
-		"html",
-		NewNormalAttr("lang", conf.lang),
+	htmlContent := HtmlTag(
+		Lang(conf.lang),
 
-		NewNormalTag("head", headList...),
-		NewNormalTag("body", body),
+		Head(headList...),
+		Body(body),
 	)
 
 	return Group(
@@ -0,0 +1,305 @@
+package hx
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+// ----------------------------------------------------
+// Requests
+// ----------------------------------------------------
+
+func HxGet[T any](urlPath urls.Path[T], params T) Elem {
+	return NewNormalAttr("hx-get", urlPath.Eval(params))
+}
+
+func HxPost[T any](urlPath urls.Path[T], params T) Elem {
+	return NewNormalAttr("hx-post", urlPath.Eval(params))
+}
+
+func HxPut[T any](urlPath urls.Path[T], params T) Elem {
+	return NewNormalAttr("hx-put", urlPath.Eval(params))
+}
+
+func HxPatch[T any](urlPath urls.Path[T], params T) Elem {
+	return NewNormalAttr("hx-patch", urlPath.Eval(params))
+}
+
+func HxDelete[T any](urlPath urls.Path[T], params T) Elem {
+	return NewNormalAttr("hx-delete", urlPath.Eval(params))
+}
+
+// ----------------------------------------------------
+// Target and Indicator
+// ----------------------------------------------------
+
+// TargetSelector is an extended css selector of htmx, used by hx-target and hx-indicator
+type TargetSelector string
+
+const (
+	TargetThis TargetSelector = "this"
+	TargetNext TargetSelector = "next"
+	TargetPrev TargetSelector = "previous"
+)
+
+func TargetID(id ElemID) TargetSelector {
+	return TargetSelector("#" + string(id))
+}
+
+// TargetCSS uses a normal css selector
+func TargetCSS(selector string) TargetSelector {
+	return TargetSelector(selector)
+}
+
+func TargetClosest(selector string) TargetSelector {
+	return TargetSelector("closest " + selector)
+}
+
+func TargetFind(selector string) TargetSelector {
+	return TargetSelector("find " + selector)
+}
+
+func TargetNextOf(selector string) TargetSelector {
+	return TargetSelector("next " + selector)
+}
+
+func TargetPrevOf(selector string) TargetSelector {
+	return TargetSelector("previous " + selector)
+}
+
+func HxTarget(target TargetSelector) Elem {
+	return NewNormalAttr("hx-target", string(target))
+}
+
+func HxIndicator(target TargetSelector) Elem {
+	return NewNormalAttr("hx-indicator", string(target))
+}
+
+// ----------------------------------------------------
+// Swap
+// ----------------------------------------------------
+
+type SwapStyle string
+
+const (
+	SwapInnerHTML   SwapStyle = "innerHTML"
+	SwapOuterHTML   SwapStyle = "outerHTML"
+	SwapTextContent SwapStyle = "textContent"
+	SwapBeforeBegin SwapStyle = "beforebegin"
+	SwapAfterBegin  SwapStyle = "afterbegin"
+	SwapBeforeEnd   SwapStyle = "beforeend"
+	SwapAfterEnd    SwapStyle = "afterend"
+	SwapDelete      SwapStyle = "delete"
+	SwapNone        SwapStyle = "none"
+)
+
+// SwapModifier is a modifier of hx-swap, e.g. swap:1s
+type SwapModifier string
+
+type ScrollPosition string
+
+const (
+	ScrollTop    ScrollPosition = "top"
+	ScrollBottom ScrollPosition = "bottom"
+)
+
+func SwapTransition() SwapModifier {
+	return "transition:true"
+}
+
+func SwapDelay(d time.Duration) SwapModifier {
+	return SwapModifier("swap:" + formatDuration(d))
+}
+
+func SettleDelay(d time.Duration) SwapModifier {
+	return SwapModifier("settle:" + formatDuration(d))
+}
+
+func SwapIgnoreTitle() SwapModifier {
+	return "ignoreTitle:true"
+}
+
+// SwapScroll scrolls the target element, or the element of the selector if not empty
+func SwapScroll(selector string, pos ScrollPosition) SwapModifier {
+	return SwapModifier("scroll:" + withSelector(selector, string(pos)))
+}
+
+// SwapShow shows the target element, or the element of the selector if not empty
+func SwapShow(selector string, pos ScrollPosition) SwapModifier {
+	return SwapModifier("show:" + withSelector(selector, string(pos)))
+}
+
+func SwapFocusScroll(enabled bool) SwapModifier {
+	return SwapModifier("focus-scroll:" + strconv.FormatBool(enabled))
+}
+
+func HxSwap(style SwapStyle, modifiers ...SwapModifier) Elem {
+	var buf strings.Builder
+	buf.WriteString(string(style))
+	for _, m := range modifiers {
+		buf.WriteString(" ")
+		buf.WriteString(string(m))
+	}
+	return NewNormalAttr("hx-swap", buf.String())
+}
+
+// ----------------------------------------------------
+// Trigger
+// ----------------------------------------------------
+
+// TriggerEvent is an event of hx-trigger with modifiers, e.g. keyup changed delay:500ms
+type TriggerEvent struct {
+	event     string
+	filter    string
+	modifiers []string
+}
+
+func TriggerOn(event string) TriggerEvent {
+	return TriggerEvent{event: event}
+}
+
+// TriggerEvery polls every interval
+func TriggerEvery(d time.Duration) TriggerEvent {
+	return TriggerEvent{event: "every " + formatDuration(d)}
+}
+
+var (
+	TriggerLoad      = TriggerOn("load")
+	TriggerRevealed  = TriggerOn("revealed")
+	TriggerIntersect = TriggerOn("intersect")
+	TriggerClick     = TriggerOn("click")
+	TriggerChange    = TriggerOn("change")
+	TriggerSubmit    = TriggerOn("submit")
+	TriggerKeyUp     = TriggerOn("keyup")
+	TriggerInput     = TriggerOn("input")
+)
+
+// Filter adds a javascript expression filter, e.g. click[ctrlKey]
+func (e TriggerEvent) Filter(expr string) TriggerEvent {
+	e.filter = expr
+	return e
+}
+
+func (e TriggerEvent) Once() TriggerEvent {
+	return e.withModifier("once")
+}
+
+func (e TriggerEvent) Changed() TriggerEvent {
+	return e.withModifier("changed")
+}
+
+func (e TriggerEvent) Delay(d time.Duration) TriggerEvent {
+	return e.withModifier("delay:" + formatDuration(d))
+}
+
+func (e TriggerEvent) Throttle(d time.Duration) TriggerEvent {
+	return e.withModifier("throttle:" + formatDuration(d))
+}
+
+// From listens the event on another element, e.g. from:body
+func (e TriggerEvent) From(target TargetSelector) TriggerEvent {
+	return e.withModifier("from:" + string(target))
+}
+
+// Target only triggers if the target of the event matches the selector
+func (e TriggerEvent) Target(selector string) TriggerEvent {
+	return e.withModifier("target:" + selector)
+}
+
+func (e TriggerEvent) Consume() TriggerEvent {
+	return e.withModifier("consume")
+}
+
+type QueueOption string
+
+const (
+	QueueFirst QueueOption = "first"
+	QueueLast  QueueOption = "last"
+	QueueAll   QueueOption = "all"
+	QueueNone  QueueOption = "none"
+)
+
+func (e TriggerEvent) Queue(option QueueOption) TriggerEvent {
+	return e.withModifier("queue:" + string(option))
+}
+
+func (e TriggerEvent) String() string {
+	var buf strings.Builder
+	buf.WriteString(e.event)
+	if len(e.filter) > 0 {
+		buf.WriteString("[" + e.filter + "]")
+	}
+	for _, m := range e.modifiers {
+		buf.WriteString(" ")
+		buf.WriteString(m)
+	}
+	return buf.String()
+}
+
+func HxTrigger(events ...TriggerEvent) Elem {
+	values := make([]string, 0, len(events))
+	for _, e := range events {
+		values = append(values, e.String())
+	}
+	return NewNormalAttr("hx-trigger", strings.Join(values, ", "))
+}
+
+// ----------------------------------------------------
+// Other Attributes
+// ----------------------------------------------------
+
+func HxSelect(selector string) Elem {
+	return NewNormalAttr("hx-select", selector)
+}
+
+// HxVals encodes the value as json, panics if the value can not be encoded
+func HxVals(value any) Elem {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode hx-vals: %v", err))
+	}
+	return NewNormalAttr("hx-vals", string(data))
+}
+
+func HxConfirm(message string) Elem {
+	return NewNormalAttr("hx-confirm", message)
+}
+
+func HxPushUrl(enabled bool) Elem {
+	return NewNormalAttr("hx-push-url", strconv.FormatBool(enabled))
+}
+
+// HxPushUrlPath pushes the url of the params instead of the request url
+func HxPushUrlPath[T any](urlPath urls.Path[T], params T) Elem {
+	return NewNormalAttr("hx-push-url", urlPath.Eval(params))
+}
+
+// ---------------------------------------------------------------------------
+// Internal Implementation
+// ---------------------------------------------------------------------------
+
+func (e TriggerEvent) withModifier(modifier string) TriggerEvent {
+	e.modifiers = append(slices.Clone(e.modifiers), modifier)
+	return e
+}
+
+// formatDuration formats using the time interval syntax of htmx, e.g. 1s or 500ms
+func formatDuration(d time.Duration) string {
+	if d%time.Second == 0 {
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	}
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
+
+func withSelector(selector string, value string) string {
+	if len(selector) == 0 {
+		return value
+	}
+	return selector + ":" + value
+}
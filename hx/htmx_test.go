@@ -0,0 +1,93 @@
+package hx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func TestHtmxAttributes(t *testing.T) {
+	urlPath := urls.New[testParams]("/users/{id}")
+
+	t.Run("requests", func(t *testing.T) {
+		params := testParams{ID: 11, Name: "a&b"}
+		elem := Div(
+			HxGet(urlPath, params),
+			HxPost(urlPath, params),
+			HxPut(urlPath, params),
+			HxPatch(urlPath, params),
+			HxDelete(urlPath, params),
+		)
+		assertSimpleContent(t,
+			`<div hx-get="/users/11?name=a%26b" hx-post="/users/11?name=a%26b" hx-put="/users/11?name=a%26b" `+
+				`hx-patch="/users/11?name=a%26b" hx-delete="/users/11?name=a%26b"></div>`,
+			elem,
+		)
+	})
+
+	t.Run("target and indicator", func(t *testing.T) {
+		assertSimpleContent(t, `<div hx-target="this"></div>`, Div(HxTarget(TargetThis)))
+		assertSimpleContent(t, `<div hx-target="#user-list"></div>`, Div(HxTarget(TargetID("user-list"))))
+		assertSimpleContent(t, `<div hx-target="closest tr"></div>`, Div(HxTarget(TargetClosest("tr"))))
+		assertSimpleContent(t, `<div hx-target="find .item"></div>`, Div(HxTarget(TargetFind(".item"))))
+		assertSimpleContent(t, `<div hx-target="next div"></div>`, Div(HxTarget(TargetNextOf("div"))))
+		assertSimpleContent(t, `<div hx-indicator=".spinner"></div>`, Div(HxIndicator(TargetCSS(".spinner"))))
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		assertSimpleContent(t, `<div hx-swap="outerHTML"></div>`, Div(HxSwap(SwapOuterHTML)))
+		assertSimpleContent(t,
+			`<div hx-swap="beforeend swap:500ms settle:1s scroll:bottom show:#top:top transition:true"></div>`,
+			Div(HxSwap(SwapBeforeEnd,
+				SwapDelay(500*time.Millisecond),
+				SettleDelay(time.Second),
+				SwapScroll("", ScrollBottom),
+				SwapShow("#top", ScrollTop),
+				SwapTransition(),
+			)),
+		)
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		keyup := TriggerKeyUp.Changed()
+		elem := Div(HxTrigger(
+			keyup.Delay(500*time.Millisecond),
+			TriggerClick.Filter("ctrlKey").Once(),
+			TriggerOn("user-updated").From(TargetCSS("body")).Throttle(2*time.Second).Queue(QueueLast),
+			TriggerEvery(time.Minute),
+		))
+		assertSimpleContent(t,
+			`<div hx-trigger="keyup changed delay:500ms, click[ctrlKey] once, `+
+				`user-updated from:body throttle:2s queue:last, every 60s"></div>`,
+			elem,
+		)
+
+		// modifiers do not change the original event
+		assert.Equal(t, "keyup changed", keyup.String())
+		assert.Equal(t, "keyup", TriggerKeyUp.String())
+	})
+
+	t.Run("others", func(t *testing.T) {
+		elem := Div(
+			HxSelect("#content"),
+			HxVals(map[string]any{"id": 11, "name": "a\"b"}),
+			HxConfirm("Are you sure?"),
+			HxPushUrl(false),
+			HxPushUrlPath(urlPath, testParams{ID: 12}),
+		)
+		assertSimpleContent(t,
+			`<div hx-select="#content" hx-vals="{&#34;id&#34;:11,&#34;name&#34;:&#34;a\&#34;b&#34;}" `+
+				`hx-confirm="Are you sure?" hx-push-url="false" hx-push-url="/users/12"></div>`,
+			elem,
+		)
+	})
+
+	t.Run("vals can not be encoded", func(t *testing.T) {
+		assert.PanicsWithValue(t, "failed to encode hx-vals: json: unsupported type: chan int", func() {
+			HxVals(make(chan int))
+		})
+	})
+}
@@ -18,6 +18,8 @@ type ElemID string
 type elemExtraInfo struct {
 	childValidator    func(child Elem, w *writerHelper)
 	afterTravelRender func(w *writerHelper)
+
+	renderErr error
 }
 
 type elemType int
@@ -31,6 +33,8 @@ const (
 	elemTypeEmptyAttribute
 	elemTypeGroup
 	elemTypeIter
+	elemTypeError
+	elemTypeSlot
 )
 
 func (e Elem) Render(writer io.Writer) error {
@@ -55,8 +59,11 @@ func (e Elem) renderWithHelper(w *writerHelper) {
 		e.children = func(yield func(Elem) bool) {}
 	}
 
-	if e.extra != nil {
+	if e.extra != nil && e.extra.childValidator != nil {
+		// validator is only applied to the element and its descendants
+		oldValidateFunc := w.validateFunc
 		w.validateFunc = e.extra.childValidator
+		defer func() { w.validateFunc = oldValidateFunc }()
 	}
 
 	switch e.elemType {
@@ -69,14 +76,13 @@ func (e Elem) renderWithHelper(w *writerHelper) {
 		w.writeBytes(openTagEnd)
 
 		for child := range e.children {
-			w.validateFunc(child, w)
 			child.renderWithHelper(w)
 			if w.err != nil {
 				return
 			}
 		}
 
-		if e.extra != nil {
+		if e.extra != nil && e.extra.afterTravelRender != nil {
 			e.extra.afterTravelRender(w)
 		}
 
@@ -98,11 +104,23 @@ func (e Elem) renderWithHelper(w *writerHelper) {
 	case elemTypeGroup:
 		for child := range e.children {
 			child.renderWithHelper(w)
+			if w.err != nil {
+				return
+			}
 		}
 
-	case elemTypeIter:
+	case elemTypeIter, elemTypeSlot:
+		// slots outside of components are rendered as groups
 		for child := range e.children {
 			child.renderWithHelper(w)
+			if w.err != nil {
+				return
+			}
+		}
+
+	case elemTypeError:
+		if w.err == nil {
+			w.err = e.extra.renderErr
 		}
 
 	default:
@@ -112,6 +130,7 @@ func (e Elem) renderWithHelper(w *writerHelper) {
 func (e Elem) renderAttribute(w *writerHelper) {
 	switch e.elemType {
 	case elemTypeAttribute:
+		w.validateFunc(e, w)
 		w.writeBytes(singleSpace)
 		w.writeBytes(e.name)
 		w.writeBytes(equalSign)
@@ -5,7 +5,9 @@ import (
 	"encoding/xml"
 	"errors"
 	"io"
+	"iter"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -233,3 +235,103 @@ func TestElem_Render__Option(t *testing.T) {
 	)
 	assertSimpleContent(t, `<option>Hello</option>`, elem)
 }
+
+func TestElem_Render__CollectWithError(t *testing.T) {
+	newSeq := func(failAt int) iter.Seq2[Elem, error] {
+		return func(yield func(Elem, error) bool) {
+			for i := range 3 {
+				if i == failAt {
+					yield(None(), errors.New("query error"))
+					return
+				}
+				if !yield(Li(Text(strconv.Itoa(i))), nil) {
+					return
+				}
+			}
+		}
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		elem := Ul(
+			CollectWithError(newSeq(-1)),
+		)
+		assertSimpleContent(t, `<ul><li>0</li><li>1</li><li>2</li></ul>`, elem)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		elem := Div(
+			Ul(
+				CollectWithError(newSeq(1)),
+			),
+			Div(),
+		)
+
+		var buf bytes.Buffer
+		err := elem.Render(&buf)
+		assert.Equal(t, errors.New("query error"), err)
+		assert.Equal(t, `<div><ul><li>0</li>`, buf.String())
+	})
+
+	t.Run("with error inside group", func(t *testing.T) {
+		elem := Group(
+			Collect(slices.Values([]Elem{
+				CollectWithError(newSeq(0)),
+				Div(),
+			})),
+			Div(),
+		)
+
+		var buf bytes.Buffer
+		err := elem.Render(&buf)
+		assert.Equal(t, errors.New("query error"), err)
+		assert.Equal(t, ``, buf.String())
+	})
+}
+
+func TestGeneratedElements(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		elem := Table(
+			Class("users"),
+			THead(Tr(Th(Scope("col"), Text("Name")))),
+			TBody(Tr(Td(ColSpan("2"), Text("user01")))),
+		)
+		assertSimpleContent(t,
+			`<table class="users"><thead><tr><th scope="col">Name</th></tr></thead>`+
+				`<tbody><tr><td colspan="2">user01</td></tr></tbody></table>`,
+			elem,
+		)
+	})
+
+	t.Run("form controls with boolean attributes", func(t *testing.T) {
+		elem := FormTag(
+			Label(For("role"), Text("Role")),
+			Select(ID("role"), Multiple(), Option(Value("a"), Selected(), Text("A"))),
+			Input(Type("checkbox"), Checked(), Disabled()),
+			Img(Src("/a.png"), Alt("a \"quoted\" image")),
+		)
+		assertSimpleContent(t,
+			`<form><label for="role">Role</label>`+
+				`<select id="role" multiple><option value="a" selected>A</option></select>`+
+				`<input type="checkbox" checked disabled>`+
+				`<img src="/a.png" alt="a &#34;quoted&#34; image"></form>`,
+			elem,
+		)
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		elem := Svg(
+			ViewBox("0 0 10 10"),
+			SvgPath(D("M0 0L10 10"), Stroke("red")),
+			SvgText(X("1"), Y("2"), Text("hi")),
+		)
+		assertSimpleContent(t,
+			`<svg viewBox="0 0 10 10"><path d="M0 0L10 10" stroke="red"></path><text x="1" y="2">hi</text></svg>`,
+			elem,
+		)
+	})
+
+	t.Run("data and aria attributes", func(t *testing.T) {
+		elem := Span(DataAttr("user-id", "11"), AriaAttr("label", "User"), TitleAttr("title"))
+		assertSimpleContent(t, `<span data-user-id="11" aria-label="User" title="title"></span>`, elem)
+	})
+}
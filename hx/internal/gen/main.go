@@ -0,0 +1,100 @@
+// Command gen generates hx/element_gen.go from the spec tables in spec.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+)
+
+const outputFile = "element_gen.go"
+
+func main() {
+	data, err := generate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "generate:", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(outputFile, data, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "write file:", err)
+		os.Exit(1)
+	}
+}
+
+func generate() ([]byte, error) {
+	if err := checkDuplicatedNames(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by hx/internal/gen; DO NOT EDIT.\n\n")
+	buf.WriteString("package hx\n")
+
+	for _, group := range tagGroups {
+		writeSectionComment(&buf, "Tags: "+group.comment)
+		for _, spec := range group.items {
+			newFunc := "NewNormalTag"
+			if spec.void {
+				newFunc = "NewSimpleTag"
+			}
+			fmt.Fprintf(&buf, "\nfunc %s(children ...Elem) Elem {\n", spec.funcName)
+			fmt.Fprintf(&buf, "\treturn %s(%q, children...)\n", newFunc, spec.tag)
+			buf.WriteString("}\n")
+		}
+	}
+
+	for _, group := range attrGroups {
+		writeSectionComment(&buf, "Attributes: "+group.comment)
+		for _, spec := range group.items {
+			switch spec.kind {
+			case attrBool:
+				fmt.Fprintf(&buf, "\nfunc %s() Elem {\n", spec.funcName)
+				fmt.Fprintf(&buf, "\treturn NewEmptyAttr(%q)\n", spec.attr)
+			case attrURL:
+				fmt.Fprintf(&buf, "\nfunc %s(urlPath string) Elem {\n", spec.funcName)
+				fmt.Fprintf(&buf, "\treturn NewURLAttr(%q, urlPath)\n", spec.attr)
+			default:
+				fmt.Fprintf(&buf, "\nfunc %s(value string) Elem {\n", spec.funcName)
+				fmt.Fprintf(&buf, "\treturn NewNormalAttr(%q, value)\n", spec.attr)
+			}
+			buf.WriteString("}\n")
+		}
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+func writeSectionComment(buf *bytes.Buffer, comment string) {
+	buf.WriteString("\n// ----------------------------------------------------\n")
+	fmt.Fprintf(buf, "// %s\n", comment)
+	buf.WriteString("// ----------------------------------------------------\n")
+}
+
+func checkDuplicatedNames() error {
+	funcNames := map[string]struct{}{}
+	addName := func(name string) error {
+		if _, existed := funcNames[name]; existed {
+			return fmt.Errorf("duplicated function name '%s'", name)
+		}
+		funcNames[name] = struct{}{}
+		return nil
+	}
+
+	for _, group := range tagGroups {
+		for _, spec := range group.items {
+			if err := addName(spec.funcName); err != nil {
+				return err
+			}
+		}
+	}
+	for _, group := range attrGroups {
+		for _, spec := range group.items {
+			if err := addName(spec.funcName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate__Up_To_Date(t *testing.T) {
+	data, err := generate()
+	assert.Equal(t, nil, err)
+
+	current, err := os.ReadFile(filepath.Join("..", "..", outputFile))
+	assert.Equal(t, nil, err)
+
+	// run `go generate ./hx` when the spec tables are changed
+	assert.Equal(t, string(current), string(data))
+}
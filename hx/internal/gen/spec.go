@@ -0,0 +1,380 @@
+package main
+
+// tagSpec is an html element, funcName is the exported function name in package hx
+type tagSpec struct {
+	funcName string
+	tag      string
+	void     bool // void element without closing tag, e.g. <br>
+}
+
+type attrKind int
+
+const (
+	attrText attrKind = iota + 1
+	attrURL           // sanitized by NewURLAttr
+	attrBool          // boolean attribute without value, e.g. checked
+)
+
+// attrSpec is an html attribute, funcName is the exported function name in package hx.
+// Attributes conflicting with element names have the suffix Attr, e.g. TitleAttr
+type attrSpec struct {
+	funcName string
+	attr     string
+	kind     attrKind
+}
+
+type specGroup[T any] struct {
+	comment string
+	items   []T
+}
+
+func tag(funcName string, name string) tagSpec {
+	return tagSpec{funcName: funcName, tag: name}
+}
+
+func voidTag(funcName string, name string) tagSpec {
+	return tagSpec{funcName: funcName, tag: name, void: true}
+}
+
+func attr(funcName string, name string) attrSpec {
+	return attrSpec{funcName: funcName, attr: name, kind: attrText}
+}
+
+func urlAttr(funcName string, name string) attrSpec {
+	return attrSpec{funcName: funcName, attr: name, kind: attrURL}
+}
+
+func boolAttr(funcName string, name string) attrSpec {
+	return attrSpec{funcName: funcName, attr: name, kind: attrBool}
+}
+
+// The element <slot> is not included, it is only used by shadow DOM of web components
+// and is not rendered by server side templates. The name Slot is used by hx.Slot of components.
+// The elements <html> and <text> of SVG are named HtmlTag and SvgText,
+// because Html and Text are already defined
+var tagGroups = []specGroup[tagSpec]{
+	{
+		comment: "Document and Metadata",
+		items: []tagSpec{
+			tag("HtmlTag", "html"),
+			tag("Head", "head"),
+			tag("Body", "body"),
+			tag("Title", "title"),
+			tag("Style", "style"),
+			tag("Script", "script"),
+			tag("NoScript", "noscript"),
+			tag("Template", "template"),
+			voidTag("Base", "base"),
+			voidTag("Link", "link"),
+			voidTag("Meta", "meta"),
+		},
+	},
+	{
+		comment: "Sections",
+		items: []tagSpec{
+			tag("Header", "header"),
+			tag("Footer", "footer"),
+			tag("Main", "main"),
+			tag("Nav", "nav"),
+			tag("Section", "section"),
+			tag("Article", "article"),
+			tag("Aside", "aside"),
+			tag("Address", "address"),
+			tag("Search", "search"),
+			tag("H1", "h1"),
+			tag("H2", "h2"),
+			tag("H3", "h3"),
+			tag("H4", "h4"),
+			tag("H5", "h5"),
+			tag("H6", "h6"),
+			tag("HGroup", "hgroup"),
+		},
+	},
+	{
+		comment: "Grouping Content",
+		items: []tagSpec{
+			tag("Div", "div"),
+			tag("P", "p"),
+			tag("Pre", "pre"),
+			tag("Blockquote", "blockquote"),
+			tag("Ul", "ul"),
+			tag("Ol", "ol"),
+			tag("Li", "li"),
+			tag("Menu", "menu"),
+			tag("Dl", "dl"),
+			tag("Dt", "dt"),
+			tag("Dd", "dd"),
+			tag("Figure", "figure"),
+			tag("FigCaption", "figcaption"),
+			voidTag("Hr", "hr"),
+		},
+	},
+	{
+		comment: "Text Level",
+		items: []tagSpec{
+			tag("A", "a"),
+			tag("Span", "span"),
+			tag("Em", "em"),
+			tag("Strong", "strong"),
+			tag("Small", "small"),
+			tag("S", "s"),
+			tag("Cite", "cite"),
+			tag("Q", "q"),
+			tag("Dfn", "dfn"),
+			tag("Abbr", "abbr"),
+			tag("Data", "data"),
+			tag("Time", "time"),
+			tag("Code", "code"),
+			tag("Var", "var"),
+			tag("Samp", "samp"),
+			tag("Kbd", "kbd"),
+			tag("Sub", "sub"),
+			tag("Sup", "sup"),
+			tag("I", "i"),
+			tag("B", "b"),
+			tag("U", "u"),
+			tag("Mark", "mark"),
+			tag("Bdi", "bdi"),
+			tag("Bdo", "bdo"),
+			tag("Ins", "ins"),
+			tag("Del", "del"),
+			voidTag("Br", "br"),
+			voidTag("Wbr", "wbr"),
+		},
+	},
+	{
+		comment: "Tables",
+		items: []tagSpec{
+			tag("Table", "table"),
+			tag("Caption", "caption"),
+			tag("ColGroup", "colgroup"),
+			tag("THead", "thead"),
+			tag("TBody", "tbody"),
+			tag("TFoot", "tfoot"),
+			tag("Tr", "tr"),
+			tag("Th", "th"),
+			tag("Td", "td"),
+			voidTag("Col", "col"),
+		},
+	},
+	{
+		comment: "Forms",
+		items: []tagSpec{
+			tag("FormTag", "form"),
+			tag("Label", "label"),
+			tag("Button", "button"),
+			tag("Select", "select"),
+			tag("DataList", "datalist"),
+			tag("OptGroup", "optgroup"),
+			tag("Option", "option"),
+			tag("TextArea", "textarea"),
+			tag("Output", "output"),
+			tag("Progress", "progress"),
+			tag("Meter", "meter"),
+			tag("FieldSet", "fieldset"),
+			tag("Legend", "legend"),
+			voidTag("Input", "input"),
+		},
+	},
+	{
+		comment: "Interactive",
+		items: []tagSpec{
+			tag("Details", "details"),
+			tag("Summary", "summary"),
+			tag("Dialog", "dialog"),
+		},
+	},
+	{
+		comment: "Embedded Content and Media",
+		items: []tagSpec{
+			tag("Picture", "picture"),
+			tag("IFrame", "iframe"),
+			tag("Object", "object"),
+			tag("Video", "video"),
+			tag("Audio", "audio"),
+			tag("Canvas", "canvas"),
+			tag("Map", "map"),
+			voidTag("Img", "img"),
+			voidTag("Source", "source"),
+			voidTag("Track", "track"),
+			voidTag("Embed", "embed"),
+			voidTag("Area", "area"),
+		},
+	},
+	{
+		comment: "SVG",
+		items: []tagSpec{
+			tag("Svg", "svg"),
+			tag("G", "g"),
+			tag("Defs", "defs"),
+			tag("Symbol", "symbol"),
+			tag("Use", "use"),
+			tag("SvgPath", "path"),
+			tag("Circle", "circle"),
+			tag("Ellipse", "ellipse"),
+			tag("Rect", "rect"),
+			tag("Line", "line"),
+			tag("Polyline", "polyline"),
+			tag("Polygon", "polygon"),
+			tag("SvgText", "text"),
+			tag("TSpan", "tspan"),
+		},
+	},
+}
+
+// The attributes class, id, name, href, src and rel are defined manually in element.go,
+// and the attribute style is defined in escape.go
+var attrGroups = []specGroup[attrSpec]{
+	{
+		comment: "Global Attributes",
+		items: []attrSpec{
+			attr("TitleAttr", "title"),
+			attr("Lang", "lang"),
+			attr("Dir", "dir"),
+			attr("Role", "role"),
+			attr("TabIndex", "tabindex"),
+			attr("AccessKey", "accesskey"),
+			attr("ContentEditable", "contenteditable"),
+			attr("Draggable", "draggable"),
+			attr("SpellCheck", "spellcheck"),
+			attr("Translate", "translate"),
+			attr("EnterKeyHint", "enterkeyhint"),
+			attr("InputMode", "inputmode"),
+			attr("Popover", "popover"),
+			attr("SlotAttr", "slot"),
+			boolAttr("Hidden", "hidden"),
+			boolAttr("Inert", "inert"),
+			boolAttr("AutoFocus", "autofocus"),
+		},
+	},
+	{
+		comment: "Links and Metadata",
+		items: []attrSpec{
+			urlAttr("Action", "action"),
+			urlAttr("FormAction", "formaction"),
+			urlAttr("Poster", "poster"),
+			urlAttr("CiteAttr", "cite"),
+			attr("Target", "target"),
+			attr("Download", "download"),
+			attr("HrefLang", "hreflang"),
+			attr("ReferrerPolicy", "referrerpolicy"),
+			attr("CrossOrigin", "crossorigin"),
+			attr("Integrity", "integrity"),
+			attr("Media", "media"),
+			attr("Sizes", "sizes"),
+			attr("SrcSet", "srcset"),
+			attr("Charset", "charset"),
+			attr("Content", "content"),
+			attr("HttpEquiv", "http-equiv"),
+			attr("As", "as"),
+			attr("Nonce", "nonce"),
+			boolAttr("Async", "async"),
+			boolAttr("Defer", "defer"),
+			boolAttr("NoModule", "nomodule"),
+		},
+	},
+	{
+		comment: "Forms",
+		items: []attrSpec{
+			attr("Type", "type"),
+			attr("Value", "value"),
+			attr("Placeholder", "placeholder"),
+			attr("For", "for"),
+			attr("FormAttr", "form"),
+			attr("Method", "method"),
+			attr("EncType", "enctype"),
+			attr("AutoComplete", "autocomplete"),
+			attr("Accept", "accept"),
+			attr("AcceptCharset", "accept-charset"),
+			attr("Min", "min"),
+			attr("Max", "max"),
+			attr("Step", "step"),
+			attr("MinLength", "minlength"),
+			attr("MaxLength", "maxlength"),
+			attr("Pattern", "pattern"),
+			attr("Size", "size"),
+			attr("Rows", "rows"),
+			attr("Cols", "cols"),
+			attr("Wrap", "wrap"),
+			attr("List", "list"),
+			attr("LabelAttr", "label"),
+			attr("High", "high"),
+			attr("Low", "low"),
+			attr("Optimum", "optimum"),
+			boolAttr("Required", "required"),
+			boolAttr("Disabled", "disabled"),
+			boolAttr("Checked", "checked"),
+			boolAttr("Selected", "selected"),
+			boolAttr("Multiple", "multiple"),
+			boolAttr("ReadOnly", "readonly"),
+			boolAttr("NoValidate", "novalidate"),
+			boolAttr("FormNoValidate", "formnovalidate"),
+		},
+	},
+	{
+		comment: "Tables",
+		items: []attrSpec{
+			attr("ColSpan", "colspan"),
+			attr("RowSpan", "rowspan"),
+			attr("SpanAttr", "span"),
+			attr("Headers", "headers"),
+			attr("Scope", "scope"),
+			attr("AbbrAttr", "abbr"),
+		},
+	},
+	{
+		comment: "Embedded Content and Media",
+		items: []attrSpec{
+			attr("Alt", "alt"),
+			attr("Width", "width"),
+			attr("Height", "height"),
+			attr("Loading", "loading"),
+			attr("Decoding", "decoding"),
+			attr("Preload", "preload"),
+			attr("Kind", "kind"),
+			attr("SrcLang", "srclang"),
+			attr("Sandbox", "sandbox"),
+			attr("Allow", "allow"),
+			attr("Coords", "coords"),
+			attr("Shape", "shape"),
+			attr("UseMap", "usemap"),
+			attr("DateTime", "datetime"),
+			boolAttr("Controls", "controls"),
+			boolAttr("AutoPlay", "autoplay"),
+			boolAttr("Loop", "loop"),
+			boolAttr("Muted", "muted"),
+			boolAttr("PlaysInline", "playsinline"),
+			boolAttr("Default", "default"),
+			boolAttr("Open", "open"),
+			boolAttr("IsMap", "ismap"),
+			boolAttr("Reversed", "reversed"),
+		},
+	},
+	{
+		comment: "SVG Attributes",
+		items: []attrSpec{
+			attr("Xmlns", "xmlns"),
+			attr("ViewBox", "viewBox"),
+			attr("Fill", "fill"),
+			attr("FillRule", "fill-rule"),
+			attr("Stroke", "stroke"),
+			attr("StrokeWidth", "stroke-width"),
+			attr("StrokeLinecap", "stroke-linecap"),
+			attr("StrokeLinejoin", "stroke-linejoin"),
+			attr("Transform", "transform"),
+			attr("D", "d"),
+			attr("Points", "points"),
+			attr("Cx", "cx"),
+			attr("Cy", "cy"),
+			attr("R", "r"),
+			attr("Rx", "rx"),
+			attr("Ry", "ry"),
+			attr("X", "x"),
+			attr("Y", "y"),
+			attr("X1", "x1"),
+			attr("Y1", "y1"),
+			attr("X2", "x2"),
+			attr("Y2", "y2"),
+		},
+	},
+}
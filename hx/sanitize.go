@@ -0,0 +1,401 @@
+package hx
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// SanitizePolicy is an allow-list of tags and attributes used for sanitizing untrusted html.
+// Disallowed tags are removed but their text content is kept, except for script, style and similar elements.
+// Disallowed attributes, event handler attributes (on*) and comments are always removed.
+// Urls of href, src, cite, action, formaction and poster are sanitized by SanitizeURL
+type SanitizePolicy struct {
+	tags        map[string]map[string]struct{} // tag name => allowed attributes
+	globalAttrs map[string]struct{}
+}
+
+func NewSanitizePolicy() *SanitizePolicy {
+	return &SanitizePolicy{
+		tags:        map[string]map[string]struct{}{},
+		globalAttrs: map[string]struct{}{},
+	}
+}
+
+// AllowTags allows the tags, without any attributes other than the global attributes
+func (p *SanitizePolicy) AllowTags(tags ...string) *SanitizePolicy {
+	for _, tag := range tags {
+		tag = strings.ToLower(tag)
+		if _, ok := p.tags[tag]; !ok {
+			p.tags[tag] = map[string]struct{}{}
+		}
+	}
+	return p
+}
+
+// AllowAttrs allows attributes of the tag, the tag is also allowed
+func (p *SanitizePolicy) AllowAttrs(tag string, attrs ...string) *SanitizePolicy {
+	p.AllowTags(tag)
+	attrSet := p.tags[strings.ToLower(tag)]
+	for _, attr := range attrs {
+		attrSet[strings.ToLower(attr)] = struct{}{}
+	}
+	return p
+}
+
+// AllowGlobalAttrs allows attributes on all allowed tags
+func (p *SanitizePolicy) AllowGlobalAttrs(attrs ...string) *SanitizePolicy {
+	for _, attr := range attrs {
+		p.globalAttrs[strings.ToLower(attr)] = struct{}{}
+	}
+	return p
+}
+
+// UGCPolicy returns a policy for user generated content, e.g. output of markdown renderers
+func UGCPolicy() *SanitizePolicy {
+	return NewSanitizePolicy().
+		AllowTags(
+			"p", "br", "hr", "div", "span",
+			"h1", "h2", "h3", "h4", "h5", "h6",
+			"b", "i", "u", "s", "em", "strong", "small", "mark", "sub", "sup", "del", "ins",
+			"code", "pre", "kbd", "samp", "blockquote",
+			"ul", "ol", "li", "dl", "dt", "dd",
+			"table", "caption", "thead", "tbody", "tfoot", "tr",
+			"figure", "figcaption",
+		).
+		AllowAttrs("a", "href", "title").
+		AllowAttrs("img", "src", "alt", "title", "width", "height").
+		AllowAttrs("th", "colspan", "rowspan", "scope").
+		AllowAttrs("td", "colspan", "rowspan").
+		AllowAttrs("ol", "start", "reversed").
+		AllowAttrs("blockquote", "cite").
+		AllowGlobalAttrs("class")
+}
+
+// SanitizeHTML sanitizes untrusted html using UGCPolicy
+func SanitizeHTML(untrusted string) TrustedHTML {
+	return UGCPolicy().Sanitize(untrusted)
+}
+
+// Sanitize removes disallowed tags and attributes, unclosed tags are closed at the end
+func (p *SanitizePolicy) Sanitize(untrusted string) TrustedHTML {
+	s := sanitizer{
+		policy: p,
+		input:  untrusted,
+	}
+	s.run()
+	return TrustedHTML{value: s.output.String()}
+}
+
+// ---------------------------------------------------------------------------
+// Internal Implementation
+// ---------------------------------------------------------------------------
+
+var voidTags = []string{
+	"area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "track", "wbr",
+}
+
+// rawTextTags are elements containing raw text instead of html, their content is removed if not allowed
+var rawTextTags = []string{
+	"script", "style", "textarea", "title", "xmp", "iframe", "noembed", "noframes", "noscript", "template",
+}
+
+var sanitizedURLAttrs = []string{"href", "src", "cite", "action", "formaction", "poster"}
+
+type htmlAttr struct {
+	name  string
+	value string
+}
+
+type sanitizer struct {
+	policy *SanitizePolicy
+
+	input string
+	pos   int
+
+	output    strings.Builder
+	openStack []string
+}
+
+func (s *sanitizer) run() {
+	for s.pos < len(s.input) {
+		next := strings.IndexByte(s.input[s.pos:], '<')
+		if next < 0 {
+			s.writeText(s.input[s.pos:])
+			break
+		}
+
+		s.writeText(s.input[s.pos : s.pos+next])
+		s.pos += next
+		s.readMarkup()
+	}
+
+	// close unclosed tags
+	for _, tag := range slices.Backward(s.openStack) {
+		s.writeEndTag(tag)
+	}
+}
+
+// readMarkup reads a tag, comment or other markup starting at '<'
+func (s *sanitizer) readMarkup() {
+	rest := s.input[s.pos:]
+
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		s.skipAfter("-->")
+
+	case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+		s.skipAfter(">")
+
+	case strings.HasPrefix(rest, "</") && len(rest) > 2 && isASCIILetter(rest[2]):
+		s.pos += 2
+		name := strings.ToLower(s.readName())
+		s.skipAfter(">")
+		s.handleEndTag(name)
+
+	case len(rest) > 1 && isASCIILetter(rest[1]):
+		s.pos++
+		name := strings.ToLower(s.readName())
+		attrs := s.readAttrs()
+		s.handleStartTag(name, attrs)
+
+	default:
+		s.writeText("<")
+		s.pos++
+	}
+}
+
+func (s *sanitizer) handleStartTag(name string, attrs []htmlAttr) {
+	allowedAttrs, allowed := s.policy.tags[name]
+
+	if slices.Contains(rawTextTags, name) {
+		content := s.readRawText(name)
+		if allowed {
+			s.writeStartTag(name, s.filterAttrs(allowedAttrs, attrs))
+			s.writeText(content)
+			s.writeEndTag(name)
+		}
+		return
+	}
+
+	if !allowed {
+		return
+	}
+
+	s.writeStartTag(name, s.filterAttrs(allowedAttrs, attrs))
+	if !slices.Contains(voidTags, name) {
+		s.openStack = append(s.openStack, name)
+	}
+}
+
+func (s *sanitizer) handleEndTag(name string) {
+	index := -1
+	for i, tag := range slices.Backward(s.openStack) {
+		if tag == name {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		// end tag without a matching start tag
+		return
+	}
+
+	// also close the inner unclosed tags
+	for len(s.openStack) > index {
+		last := len(s.openStack) - 1
+		s.writeEndTag(s.openStack[last])
+		s.openStack = s.openStack[:last]
+	}
+}
+
+func (s *sanitizer) filterAttrs(allowedAttrs map[string]struct{}, attrs []htmlAttr) []htmlAttr {
+	var result []htmlAttr
+	for _, attr := range attrs {
+		if strings.HasPrefix(attr.name, "on") {
+			continue
+		}
+
+		_, ok := allowedAttrs[attr.name]
+		_, isGlobal := s.policy.globalAttrs[attr.name]
+		if !ok && !isGlobal {
+			continue
+		}
+
+		duplicated := slices.ContainsFunc(result, func(a htmlAttr) bool { return a.name == attr.name })
+		if duplicated {
+			continue
+		}
+
+		if slices.Contains(sanitizedURLAttrs, attr.name) {
+			attr.value = string(SanitizeURL(attr.value))
+		}
+		result = append(result, attr)
+	}
+	return result
+}
+
+func (s *sanitizer) writeStartTag(name string, attrs []htmlAttr) {
+	s.output.WriteString("<")
+	s.output.WriteString(name)
+	for _, attr := range attrs {
+		s.output.WriteString(" ")
+		s.output.WriteString(attr.name)
+		s.output.WriteString(`="`)
+		s.output.WriteString(html.EscapeString(attr.value))
+		s.output.WriteString(`"`)
+	}
+	s.output.WriteString(">")
+}
+
+func (s *sanitizer) writeEndTag(name string) {
+	s.output.WriteString("</")
+	s.output.WriteString(name)
+	s.output.WriteString(">")
+}
+
+// writeText decodes character references then escapes again
+func (s *sanitizer) writeText(text string) {
+	s.output.WriteString(html.EscapeString(html.UnescapeString(text)))
+}
+
+func (s *sanitizer) readName() string {
+	begin := s.pos
+	for s.pos < len(s.input) && !isNameEnd(s.input[s.pos]) {
+		s.pos++
+	}
+	return s.input[begin:s.pos]
+}
+
+// readAttrs reads attributes until the end of the start tag
+func (s *sanitizer) readAttrs() []htmlAttr {
+	var attrs []htmlAttr
+	for {
+		s.skipSpaces()
+		if s.pos >= len(s.input) {
+			return attrs
+		}
+
+		switch s.input[s.pos] {
+		case '>':
+			s.pos++
+			return attrs
+		case '/':
+			s.pos++
+			continue
+		default:
+		}
+
+		name := strings.ToLower(s.readAttrName())
+		s.skipSpaces()
+
+		var value string
+		if s.pos < len(s.input) && s.input[s.pos] == '=' {
+			s.pos++
+			s.skipSpaces()
+			value = html.UnescapeString(s.readAttrValue())
+		}
+		attrs = append(attrs, htmlAttr{name: name, value: value})
+	}
+}
+
+func (s *sanitizer) readAttrName() string {
+	begin := s.pos
+	// the first character can be '=', as in html parsers
+	s.pos++
+	for s.pos < len(s.input) && !isNameEnd(s.input[s.pos]) && s.input[s.pos] != '=' {
+		s.pos++
+	}
+	return s.input[begin:s.pos]
+}
+
+func (s *sanitizer) readAttrValue() string {
+	if s.pos >= len(s.input) {
+		return ""
+	}
+
+	quote := s.input[s.pos]
+	if quote == '"' || quote == '\'' {
+		s.pos++
+		end := strings.IndexByte(s.input[s.pos:], quote)
+		if end < 0 {
+			value := s.input[s.pos:]
+			s.pos = len(s.input)
+			return value
+		}
+		value := s.input[s.pos : s.pos+end]
+		s.pos += end + 1
+		return value
+	}
+
+	begin := s.pos
+	for s.pos < len(s.input) && !isSpace(s.input[s.pos]) && s.input[s.pos] != '>' {
+		s.pos++
+	}
+	return s.input[begin:s.pos]
+}
+
+// readRawText reads content until the end tag of the raw text element, the end tag is also consumed
+func (s *sanitizer) readRawText(name string) string {
+	rest := s.input[s.pos:]
+	end := indexEndTag(rest, name)
+	if end < 0 {
+		s.pos = len(s.input)
+		return rest
+	}
+
+	s.pos += end
+	s.skipAfter(">")
+	return rest[:end]
+}
+
+// indexEndTag finds the end tag of name ignoring only ASCII case, the byte offsets of input are kept unchanged
+func indexEndTag(input string, name string) int {
+	endTag := "</" + name
+	for i := 0; i+len(endTag) <= len(input); i++ {
+		if equalASCIIFold(input[i:i+len(endTag)], endTag) {
+			return i
+		}
+	}
+	return -1
+}
+
+func equalASCIIFold(a string, lowerB string) bool {
+	for i := 0; i < len(a); i++ {
+		c := a[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != lowerB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *sanitizer) skipAfter(pattern string) {
+	index := strings.Index(s.input[s.pos:], pattern)
+	if index < 0 {
+		s.pos = len(s.input)
+		return
+	}
+	s.pos += index + len(pattern)
+}
+
+func (s *sanitizer) skipSpaces() {
+	for s.pos < len(s.input) && isSpace(s.input[s.pos]) {
+		s.pos++
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isNameEnd(c byte) bool {
+	return isSpace(c) || c == '/' || c == '>'
+}
+
+func isASCIILetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -3,11 +3,17 @@ package hx
 import (
 	"bytes"
 	"fmt"
+	"html"
+	"iter"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/QuangTung97/weblib/urls"
 )
 
+// StrictForm checks input names of descendants are non path params of the url path,
+// and urls of its own action and hx-* attributes match the url path
 func StrictForm[T any](
 	urlPath urls.Path[T],
 	children ...Elem,
@@ -19,8 +25,9 @@ func StrictForm[T any](
 
 	e := NewNormalTag("form", children...)
 
-	var violated bool
-	var violatedName string
+	// check urls of the form's own attributes
+	violation, violationMsg := checkTargetAttrs(urlPath, children)
+	violated := len(violation) > 0
 
 	validateFunc := func(child Elem, w *writerHelper) {
 		if child.elemType != elemTypeAttribute {
@@ -38,7 +45,8 @@ func StrictForm[T any](
 
 		if !violated {
 			violated = true
-			violatedName = nameKey
+			violation = nameKey
+			violationMsg = fmt.Sprintf("StrictForm violation on input name '%s'", nameKey)
 		}
 	}
 
@@ -50,20 +58,134 @@ func StrictForm[T any](
 			}
 
 			// log using slog
-			StrictFormErrorLogFunc(violatedName)
-
-			validateErrorMsg := fmt.Sprintf("StrictForm violation on input name '%s'", violatedName)
-			elem := Div(
-				Class("text-2xl text-red-700"),
-				Text(validateErrorMsg),
-			)
-			elem.renderWithHelper(w)
+			StrictFormErrorLogFunc(violation)
+			violationElem(violationMsg).renderWithHelper(w)
 		},
 	}
 
 	return e
 }
 
+// StrictFormFor is a StrictForm targeting the url of the params.
+// GET and POST use method and action attributes, PUT, PATCH and DELETE use hx-put, hx-patch and hx-delete.
+// Query of the url is removed for GET, since browsers replace it with form values
+func StrictFormFor[T any](
+	method string, urlPath urls.Path[T], params T,
+	children ...Elem,
+) Elem {
+	targetURL := urlPath.Eval(params)
+
+	var attrs Elem
+	switch strings.ToUpper(method) {
+	case "GET":
+		targetURL, _, _ = strings.Cut(targetURL, "?")
+		attrs = Group(Method("get"), Action(targetURL))
+	case "POST":
+		attrs = Group(Method("post"), Action(targetURL))
+	case "PUT", "PATCH", "DELETE":
+		attrs = NewNormalAttr("hx-"+strings.ToLower(method), targetURL)
+	default:
+		panic(fmt.Sprintf("not support method '%s' of form", method))
+	}
+
+	return StrictForm(urlPath, append([]Elem{attrs}, children...)...)
+}
+
+// StrictLink renders a link with href generated from the url of the params,
+// urls of its own href and hx-* attributes are checked like StrictForm
+func StrictLink[T any](urlPath urls.Path[T], params T, children ...Elem) Elem {
+	e := A(append([]Elem{Href(urlPath.Eval(params))}, children...)...)
+
+	violation, violationMsg := checkTargetAttrs(urlPath, children)
+	if len(violation) == 0 {
+		return e
+	}
+
+	e.extra = &elemExtraInfo{
+		afterTravelRender: func(w *writerHelper) {
+			StrictFormErrorLogFunc(violation)
+			violationElem(violationMsg).renderWithHelper(w)
+		},
+	}
+	return e
+}
+
+// StrictFormViolation renders the violation message the same way as StrictForm,
+// the violation is logged with StrictFormErrorLogFunc at render time
+func StrictFormViolation(violation string, violationMsg string) Elem {
+	return Collect(func(yield func(Elem) bool) {
+		StrictFormErrorLogFunc(violation)
+		yield(violationElem(violationMsg))
+	})
+}
+
+// StrictFormErrorLogFunc is called on violations of StrictForm and StrictLink,
+// with the input name or the attribute with its url, e.g. hx-post=/users
 var StrictFormErrorLogFunc = func(inputName string) {
 	slog.Error("strict form violation", "name", inputName)
 }
+
+// ---------------------------------------------------------------------------
+// Internal Implementation
+// ---------------------------------------------------------------------------
+
+func violationElem(violationMsg string) Elem {
+	return Div(
+		Class("text-2xl text-red-700"),
+		Text(violationMsg),
+	)
+}
+
+var strictTargetAttrs = []string{
+	"action", "href",
+	"hx-get", "hx-post", "hx-put", "hx-patch", "hx-delete",
+}
+
+// checkTargetAttrs returns the first attribute with url not matching the url path
+func checkTargetAttrs[T any](urlPath urls.Path[T], children []Elem) (violation string, violationMsg string) {
+	for attr := range ownAttributes(slices.Values(children)) {
+		attrName := string(attr.name)
+		if !slices.Contains(strictTargetAttrs, attrName) {
+			continue
+		}
+
+		targetURL := html.UnescapeString(string(attr.value))
+		if urlPath.Match(targetURL) {
+			continue
+		}
+
+		violation = attrName + "=" + targetURL
+		violationMsg = fmt.Sprintf(
+			"StrictForm violation on attribute %s='%s' not match url path '%s'",
+			attrName, targetURL, urlPath.GetPattern(),
+		)
+		return violation, violationMsg
+	}
+	return "", ""
+}
+
+// ownAttributes returns attributes of an element, including attributes inside groups
+func ownAttributes(children iter.Seq[Elem]) iter.Seq[Elem] {
+	return func(yield func(Elem) bool) {
+		for child := range children {
+			switch child.elemType {
+			case elemTypeAttribute:
+				if !yield(child) {
+					return
+				}
+
+			case elemTypeGroup:
+				if child.children == nil {
+					continue
+				}
+				for attr := range ownAttributes(child.children) {
+					if !yield(attr) {
+						return
+					}
+				}
+
+			default:
+			}
+		}
+	}
+}
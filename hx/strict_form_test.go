@@ -3,6 +3,8 @@ package hx
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/QuangTung97/weblib/urls"
 )
 
@@ -53,3 +55,152 @@ func TestStrictForm(t *testing.T) {
 		assertXmlContent(t, expected, elem)
 	})
 }
+
+func TestStrictForm__Target_Attributes(t *testing.T) {
+	urlPath := urls.New[testParams]("/users/{id}")
+
+	var violations []string
+	oldLogFunc := StrictFormErrorLogFunc
+	StrictFormErrorLogFunc = func(inputName string) {
+		violations = append(violations, inputName)
+	}
+	t.Cleanup(func() {
+		StrictFormErrorLogFunc = oldLogFunc
+	})
+
+	t.Run("matched urls", func(t *testing.T) {
+		violations = nil
+		elem := StrictForm(urlPath,
+			NewNormalAttr("hx-post", "/users/12?name=a&b=c"),
+			Input(Name("name")),
+		)
+		assertSimpleContent(t,
+			`<form hx-post="/users/12?name=a&amp;b=c"><input name="name"></form>`,
+			elem,
+		)
+		assert.Equal(t, []string(nil), violations)
+	})
+
+	t.Run("not matched url", func(t *testing.T) {
+		violations = nil
+		elem := StrictForm(urlPath,
+			Group(NewNormalAttr("action", "/accounts/12")),
+			Input(Name("name")),
+		)
+		assertSimpleContent(t,
+			`<form action="/accounts/12"><input name="name">`+
+				`<div class="text-2xl text-red-700">StrictForm violation on attribute action=&#39;/accounts/12&#39; `+
+				`not match url path &#39;/users/{id}&#39;</div></form>`,
+			elem,
+		)
+		assert.Equal(t, []string{"action=/accounts/12"}, violations)
+	})
+
+	t.Run("urls of descendants are not checked", func(t *testing.T) {
+		violations = nil
+		elem := StrictForm(urlPath,
+			Button(NewNormalAttr("hx-get", "/other")),
+		)
+		assertSimpleContent(t, `<form><button hx-get="/other"></button></form>`, elem)
+		assert.Equal(t, []string(nil), violations)
+	})
+
+	t.Run("input name of simple tag", func(t *testing.T) {
+		violations = nil
+		elem := Div(
+			StrictForm(urlPath, Input(Group(Name("age")))),
+			Input(Name("outside")),
+		)
+		assertSimpleContent(t,
+			`<div><form><input name="age">`+
+				`<div class="text-2xl text-red-700">StrictForm violation on input name &#39;age&#39;</div>`+
+				`</form><input name="outside"></div>`,
+			elem,
+		)
+		assert.Equal(t, []string{"age"}, violations)
+	})
+}
+
+func TestStrictFormFor(t *testing.T) {
+	urlPath := urls.New[testParams]("/users/{id}")
+	params := testParams{ID: 11, Name: "user01"}
+
+	t.Run("get", func(t *testing.T) {
+		elem := StrictFormFor("get", urlPath, params, Input(Name("name")))
+		assertSimpleContent(t,
+			`<form method="get" action="/users/11"><input name="name"></form>`,
+			elem,
+		)
+	})
+
+	t.Run("post", func(t *testing.T) {
+		elem := StrictFormFor("POST", urlPath, params)
+		assertSimpleContent(t, `<form method="post" action="/users/11?name=user01"></form>`, elem)
+	})
+
+	t.Run("put, patch and delete", func(t *testing.T) {
+		assertSimpleContent(t, `<form hx-put="/users/11?name=user01"></form>`, StrictFormFor("PUT", urlPath, params))
+		assertSimpleContent(t, `<form hx-patch="/users/11?name=user01"></form>`, StrictFormFor("PATCH", urlPath, params))
+		assertSimpleContent(t, `<form hx-delete="/users/11?name=user01"></form>`, StrictFormFor("DELETE", urlPath, params))
+	})
+
+	t.Run("not supported method", func(t *testing.T) {
+		assert.PanicsWithValue(t, "not support method 'OPTIONS' of form", func() {
+			StrictFormFor("OPTIONS", urlPath, params)
+		})
+	})
+}
+
+func TestStrictLink(t *testing.T) {
+	urlPath := urls.New[testParams]("/users/{id}")
+
+	var violations []string
+	oldLogFunc := StrictFormErrorLogFunc
+	StrictFormErrorLogFunc = func(inputName string) {
+		violations = append(violations, inputName)
+	}
+	t.Cleanup(func() {
+		StrictFormErrorLogFunc = oldLogFunc
+	})
+
+	t.Run("normal", func(t *testing.T) {
+		violations = nil
+		elem := StrictLink(urlPath, testParams{ID: 11, Name: "a&b"},
+			NewNormalAttr("hx-boost", "true"),
+			Text("User"),
+		)
+		assertSimpleContent(t, `<a href="/users/11?name=a%26b" hx-boost="true">User</a>`, elem)
+		assert.Equal(t, []string(nil), violations)
+	})
+
+	t.Run("with not matched hx-get", func(t *testing.T) {
+		violations = nil
+		elem := StrictLink(urlPath, testParams{ID: 11},
+			NewNormalAttr("hx-get", "/accounts/11"),
+		)
+		assertSimpleContent(t,
+			`<a href="/users/11" hx-get="/accounts/11"><div class="text-2xl text-red-700">`+
+				`StrictForm violation on attribute hx-get=&#39;/accounts/11&#39; not match url path &#39;/users/{id}&#39;`+
+				`</div></a>`,
+			elem,
+		)
+		assert.Equal(t, []string{"hx-get=/accounts/11"}, violations)
+	})
+}
+
+func TestStrictFormViolation(t *testing.T) {
+	var violations []string
+	oldLogFunc := StrictFormErrorLogFunc
+	StrictFormErrorLogFunc = func(inputName string) {
+		violations = append(violations, inputName)
+	}
+	t.Cleanup(func() {
+		StrictFormErrorLogFunc = oldLogFunc
+	})
+
+	elem := StrictFormViolation("/users", "some violation")
+	assert.Equal(t, []string(nil), violations)
+
+	assertSimpleContent(t, `<div class="text-2xl text-red-700">some violation</div>`, elem)
+	assert.Equal(t, []string{"/users"}, violations)
+}
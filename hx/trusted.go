@@ -0,0 +1,55 @@
+package hx
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// TrustedHTML is html content rendered by Raw without escaping.
+// It can only be created by the named constructors in this package:
+// TrustedHTMLFromConstant, TrustedHTMLFromFS and the sanitizer
+type TrustedHTML struct {
+	value string
+}
+
+// constantHTML is unexported, so only untyped string constants can be passed to TrustedHTMLFromConstant
+type constantHTML string
+
+// TrustedHTMLFromConstant creates TrustedHTML from a string constant written by developers, e.g.
+//
+//	hx.TrustedHTMLFromConstant(`<svg viewBox="0 0 10 10"></svg>`)
+//
+// Variables of type string can not be passed, to avoid embedding untrusted input by mistake
+func TrustedHTMLFromConstant(content constantHTML) TrustedHTML {
+	return TrustedHTML{value: string(content)}
+}
+
+// TrustedHTMLFromFS reads a trusted file, e.g. svg icons embedded with embed.FS
+func TrustedHTMLFromFS(fsys fs.FS, name string) (TrustedHTML, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return TrustedHTML{}, fmt.Errorf("read trusted html: %w", err)
+	}
+	return TrustedHTML{value: string(data)}, nil
+}
+
+// MustTrustedHTMLFromFS is similar to TrustedHTMLFromFS, but panics on error
+func MustTrustedHTMLFromFS(fsys fs.FS, name string) TrustedHTML {
+	content, err := TrustedHTMLFromFS(fsys, name)
+	if err != nil {
+		panic(err.Error())
+	}
+	return content
+}
+
+func (h TrustedHTML) String() string {
+	return h.value
+}
+
+// Raw renders the trusted html content without escaping
+func Raw(content TrustedHTML) Elem {
+	return Elem{
+		elemType: elemTypeContent,
+		value:    []byte(content.value),
+	}
+}
@@ -0,0 +1,127 @@
+package hx
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaw(t *testing.T) {
+	t.Run("from constant", func(t *testing.T) {
+		icon := TrustedHTMLFromConstant(`<svg viewBox="0 0 10 10"><path d="M0 0"></path></svg>`)
+		elem := Span(Class("icon"), Raw(icon))
+		assertSimpleContent(t,
+			`<span class="icon"><svg viewBox="0 0 10 10"><path d="M0 0"></path></svg></span>`,
+			elem,
+		)
+	})
+
+	t.Run("from fs", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"icons/user.svg": {Data: []byte(`<svg></svg>`)},
+		}
+
+		icon, err := TrustedHTMLFromFS(fsys, "icons/user.svg")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, `<svg></svg>`, icon.String())
+
+		_, err = TrustedHTMLFromFS(fsys, "icons/not-found.svg")
+		assert.Equal(t, true, errors.Is(err, fs.ErrNotExist))
+
+		assert.Panics(t, func() {
+			MustTrustedHTMLFromFS(fsys, "icons/not-found.svg")
+		})
+	})
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "allowed tags",
+			input:    `<p class="intro">Hello <b>world</b><br/>next</p>`,
+			expected: `<p class="intro">Hello <b>world</b><br>next</p>`,
+		},
+		{
+			name:     "script and style are removed with content",
+			input:    `<p>a<script>alert("x")</script>b<STYLE>p{}</style>c</p>`,
+			expected: `<p>abc</p>`,
+		},
+		{
+			name:     "script with multi-byte lower case content",
+			input:    "a<script>" + strings.Repeat("Ⱥ", 20) + "</script>b",
+			expected: `ab`,
+		},
+		{
+			name:     "script content with dotted capital i",
+			input:    "a<script>İİİ</script><b>x</b>",
+			expected: `a<b>x</b>`,
+		},
+		{
+			name:     "disallowed tags keep text content",
+			input:    `<form action="/x"><button>Click</button></form>`,
+			expected: `Click`,
+		},
+		{
+			name:     "event handlers and disallowed attributes",
+			input:    `<img src="/a.png" onerror="alert(1)" style="x" alt='a "b"'>`,
+			expected: `<img src="/a.png" alt="a &#34;b&#34;">`,
+		},
+		{
+			name:     "unsafe urls",
+			input:    `<a href="jav&#x09;ascript:alert(1)" title=t>x</a><a href="JAVASCRIPT:alert(1)">y</a>`,
+			expected: `<a href="about:invalid#hx-unsafe-url" title="t">x</a><a href="about:invalid#hx-unsafe-url">y</a>`,
+		},
+		{
+			name:     "comments and doctype",
+			input:    `<!DOCTYPE html><!-- <script>alert(1)</script> -->text`,
+			expected: `text`,
+		},
+		{
+			name:     "unclosed and misnested tags",
+			input:    `<ul><li><em>a</li><li>b</div></ul><p>c`,
+			expected: `<ul><li><em>a</em></li><li>b</li></ul><p>c</p>`,
+		},
+		{
+			name:     "text is escaped",
+			input:    `1 < 2 & 3 > 2 &amp; &lt;script&gt;`,
+			expected: `1 &lt; 2 &amp; 3 &gt; 2 &amp; &lt;script&gt;`,
+		},
+		{
+			name:     "unterminated tag",
+			input:    `<b>bold</b><a href="/x`,
+			expected: `<b>bold</b><a href="/x"></a>`,
+		},
+		{
+			name:     "duplicated attributes",
+			input:    `<a href="/a" href="javascript:x">a</a>`,
+			expected: `<a href="/a">a</a>`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, SanitizeHTML(c.input).String())
+		})
+	}
+}
+
+func TestSanitizePolicy(t *testing.T) {
+	policy := NewSanitizePolicy().
+		AllowTags("p").
+		AllowAttrs("a", "href", "onclick").
+		AllowGlobalAttrs("id")
+
+	result := policy.Sanitize(`<p id="x" class="y">a<a href="/b" onclick="c()" id="z">b</a><b>c</b></p>`)
+	assert.Equal(t, `<p id="x">a<a href="/b" id="z">b</a>c</p>`, result.String())
+
+	elem := Div(Raw(result))
+	assertSimpleContent(t, `<div><p id="x">a<a href="/b" id="z">b</a>c</p></div>`, elem)
+}
@@ -14,14 +14,27 @@ type Context struct {
 
 type contextState struct {
 	responded bool
+
+	hxTriggers map[string][]hxTriggerEvent
+
+	values map[any]any
+
+	// pending response status and cookies, applied right before the headers are written
+	status        int
+	cookies       []*http.Cookie
+	headerWritten bool
 }
 
 func NewContext(writer http.ResponseWriter, req *http.Request) Context {
+	state := &contextState{}
 	return Context{
 		Request: req,
-		writer:  writer,
+		writer: &responseWriter{
+			writer: writer,
+			state:  state,
+		},
 
-		state: &contextState{},
+		state: state,
 	}
 }
 
@@ -29,15 +42,107 @@ func (c Context) Context() context.Context {
 	return c.Request.Context()
 }
 
+// GetWriter returns the response writer.
+// The pending status and cookies are applied when the headers are written by this writer
 func (c Context) GetWriter() http.ResponseWriter {
 	return c.writer
 }
 
+// HttpRedirect redirects with status 307.
+// For htmx requests, the HX-Redirect header is used instead
 func (c Context) HttpRedirect(redirectURL string) {
+	if c.IsHxRequest() {
+		c.HxRedirect(redirectURL)
+		return
+	}
+
 	c.state.responded = true
 	http.Redirect(c.writer, c.Request, redirectURL, http.StatusTemporaryRedirect)
 }
 
 func (c Context) IsHxRequest() bool {
-	return c.Request.Header.Get("Hx-Request") == "true"
+	return c.Request.Header.Get(hxRequestHeader) == "true"
+}
+
+// SetStatus sets the status code of the response, default is 200
+func (c Context) SetStatus(status int) {
+	c.state.status = status
+}
+
+// SetHeader sets the response header, replacing existing values
+func (c Context) SetHeader(key string, value string) {
+	c.writer.Header().Set(key, value)
+}
+
+// AddHeader appends the value to the response header, keeping values set by other helpers
+func (c Context) AddHeader(key string, value string) {
+	c.writer.Header().Add(key, value)
+}
+
+// SetCookie adds a Set-Cookie header to the response
+func (c Context) SetCookie(cookie *http.Cookie) {
+	c.state.cookies = append(c.state.cookies, cookie)
+}
+
+// ClearCookie deletes the cookie with path '/' on the client
+func (c Context) ClearCookie(name string) {
+	c.SetCookie(&http.Cookie{
+		Name:   name,
+		Value:  "",
+		MaxAge: -1,
+		Path:   "/",
+	})
+}
+
+// -------------------------------------------------------------------------
+// Internal Implementation
+// -------------------------------------------------------------------------
+
+func (s *contextState) getStatus() int {
+	if s.status == 0 {
+		return http.StatusOK
+	}
+	return s.status
+}
+
+// responseWriter makes sure headers are only written once
+type responseWriter struct {
+	writer http.ResponseWriter
+	state  *contextState
+}
+
+var _ http.Flusher = &responseWriter{}
+
+func (w *responseWriter) Header() http.Header {
+	return w.writer.Header()
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.state.headerWritten {
+		return
+	}
+	w.state.headerWritten = true
+
+	for _, cookie := range w.state.cookies {
+		http.SetCookie(w.writer, cookie)
+	}
+
+	w.writer.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.WriteHeader(w.state.getStatus())
+	return w.writer.Write(data)
+}
+
+func (w *responseWriter) Flush() {
+	w.WriteHeader(w.state.getStatus())
+	if flusher, ok := w.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap is used by http.ResponseController
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.writer
 }
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"fmt"
+)
+
+// ContextKey is a typed key for storing per-request values inside Context.
+// Values are shared between middlewares, finalize hooks, the handler and the error handler of the same request
+type ContextKey[T any] struct {
+	name string
+}
+
+// NewContextKey creates a new key, each key is distinguished by its pointer, not by its name
+func NewContextKey[T any](name string) *ContextKey[T] {
+	return &ContextKey[T]{name: name}
+}
+
+func (k *ContextKey[T]) String() string {
+	return k.name
+}
+
+// Set stores the value, replacing the old one if existed
+func (k *ContextKey[T]) Set(ctx Context, value T) {
+	if ctx.state.values == nil {
+		ctx.state.values = map[any]any{}
+	}
+	ctx.state.values[k] = value
+}
+
+// Get returns the stored value, and false if not found
+func (k *ContextKey[T]) Get(ctx Context) (T, bool) {
+	val, ok := ctx.state.values[k]
+	if !ok {
+		var empty T
+		return empty, false
+	}
+	return val.(T), true
+}
+
+// MustGet returns the stored value, panics if not found
+func (k *ContextKey[T]) MustGet(ctx Context) T {
+	val, ok := k.Get(ctx)
+	if !ok {
+		panic(fmt.Sprintf("missing value of context key '%s'", k.name))
+	}
+	return val
+}
+
+// Delete removes the stored value
+func (k *ContextKey[T]) Delete(ctx Context) {
+	delete(ctx.state.values, k)
+}
@@ -0,0 +1,100 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+type testUser struct {
+	ID   int64
+	Name string
+}
+
+func TestContextKey(t *testing.T) {
+	t.Run("set and get", func(t *testing.T) {
+		ctx := NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		userKey := NewContextKey[testUser]("user")
+		localeKey := NewContextKey[string]("locale")
+		otherLocaleKey := NewContextKey[string]("locale")
+
+		// get empty
+		user, ok := userKey.Get(ctx)
+		assert.Equal(t, false, ok)
+		assert.Equal(t, testUser{}, user)
+
+		userKey.Set(ctx, testUser{ID: 11, Name: "user01"})
+		localeKey.Set(ctx, "vi")
+
+		user, ok = userKey.Get(ctx)
+		assert.Equal(t, true, ok)
+		assert.Equal(t, testUser{ID: 11, Name: "user01"}, user)
+		assert.Equal(t, "vi", localeKey.MustGet(ctx))
+
+		// same name but different key
+		_, ok = otherLocaleKey.Get(ctx)
+		assert.Equal(t, false, ok)
+
+		// delete
+		localeKey.Delete(ctx)
+		_, ok = localeKey.Get(ctx)
+		assert.Equal(t, false, ok)
+
+		assert.PanicsWithValue(t, "missing value of context key 'locale'", func() {
+			localeKey.MustGet(ctx)
+		})
+	})
+
+	t.Run("delete when empty", func(t *testing.T) {
+		ctx := NewContext(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		userKey := NewContextKey[testUser]("user")
+		userKey.Delete(ctx)
+		assert.Equal(t, "user", userKey.String())
+	})
+}
+
+func TestContextKey__Shared_Between_Middleware_And_Handler(t *testing.T) {
+	h := newHtmlTest()
+
+	userKey := NewContextKey[testUser]("user")
+
+	h.router = h.router.WithMiddlewares(
+		func(handler GenericHandler) GenericHandler {
+			return func(ctx Context, req any) (any, error) {
+				userKey.Set(ctx, testUser{ID: 21, Name: "user02"})
+				return handler(ctx, req)
+			}
+		},
+	)
+	h.router.AddFinalizeHook(func(handler GenericHandler) GenericHandler {
+		return func(ctx Context, req any) (any, error) {
+			h.addAction("hook: " + userKey.MustGet(ctx).Name)
+			return handler(ctx, req)
+		}
+	})
+
+	h.router.SetCustomHtmlErrorHandler(func(ctx Context, err error) {
+		h.addAction("error handler: " + userKey.MustGet(ctx).Name)
+	})
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		h.addAction("handler: " + userKey.MustGet(ctx).Name)
+		return hx.None(), errors.New("handler error")
+	})
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, []string{
+		"hook: user02",
+		"handler: user02",
+		"error handler: user02",
+	}, h.actions)
+}
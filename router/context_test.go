@@ -0,0 +1,151 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func TestContext_SetStatus_Header_Cookie(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlPost(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		ctx.SetStatus(http.StatusUnprocessableEntity)
+		ctx.SetHeader("X-Request-Id", "req01")
+		ctx.AddHeader("X-Tag", "tag01")
+		ctx.AddHeader("X-Tag", "tag02")
+		ctx.SetCookie(&http.Cookie{Name: "session_id", Value: "sess01", Path: "/"})
+		ctx.ClearCookie("old_session")
+		return hx.Div(hx.Text("invalid form")), nil
+	})
+
+	h.doMethod(http.MethodPost, "/users/123", url.Values{})
+
+	// check output
+	assert.Equal(t, http.StatusUnprocessableEntity, h.writer.Code)
+	assert.Equal(t, "<div>invalid form</div>", h.writer.Body.String())
+
+	// check headers
+	assert.Equal(t, http.Header{
+		"Content-Type": {"text/html; charset=utf-8"},
+		"X-Request-Id": {"req01"},
+		"X-Tag":        {"tag01", "tag02"},
+		"Set-Cookie": {
+			"session_id=sess01; Path=/",
+			"old_session=; Path=/; Max-Age=0",
+		},
+	}, h.writer.Header())
+}
+
+func TestContext_SetStatus__Empty_Response(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlDelete(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		ctx.SetStatus(http.StatusNoContent)
+		ctx.SetHeader("X-Deleted", "true")
+		return hx.None(), nil
+	})
+
+	h.doMethod(http.MethodDelete, "/users/123", nil)
+
+	assert.Equal(t, http.StatusNoContent, h.writer.Code)
+	assert.Equal(t, "", h.writer.Body.String())
+	assert.Equal(t, "true", h.writer.Header().Get("X-Deleted"))
+}
+
+func TestContext_SetStatus__Json_Created(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+	JsonPost(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		ctx.SetStatus(http.StatusCreated)
+		return jsonResponse{Message: "created"}, nil
+	})
+
+	h.doJson(http.MethodPost, "/api/users/123", "{}")
+
+	assert.Equal(t, http.StatusCreated, h.writer.Code)
+	assert.Equal(t, `{"message":"created","count":0}`+"\n", h.writer.Body.String())
+}
+
+func TestContext_SetHeader__With_Redirect(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		ctx.SetCookie(&http.Cookie{Name: "flash", Value: "saved"})
+		ctx.HttpRedirect("/home")
+		return hx.None(), nil
+	})
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, http.StatusTemporaryRedirect, h.writer.Code)
+	assert.Equal(t, http.Header{
+		"Content-Type": {"text/html; charset=utf-8"},
+		"Location":     {"/home"},
+		"Set-Cookie":   {"flash=saved"},
+	}, h.writer.Header())
+}
+
+func TestContext_SetStatus__Handler_Error__Error_Status_Is_Used(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		ctx.SetStatus(http.StatusCreated)
+		ctx.SetHeader("X-Request-Id", "req02")
+		return hx.None(), errors.New("handler error")
+	})
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t, htmlErrorPage("500 Internal Server Error", "Internal Server Error"), h.writer.Body.String())
+	assert.Equal(t, "req02", h.writer.Header().Get("X-Request-Id"))
+}
+
+func TestContext_GetWriter__Write_Header_Only_Once(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		writer := ctx.GetWriter()
+		writer.WriteHeader(http.StatusAccepted)
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("accepted"))
+		return hx.None(), nil
+	})
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, http.StatusAccepted, h.writer.Code)
+	assert.Equal(t, "accepted", h.writer.Body.String())
+}
+
+func TestContext_AddHeader__Keep_Headers_Of_Helpers(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	ViewGet(h.router, urlPath, func(ctx Context, params htmlParams) (userView, error) {
+		ctx.AddHeader("Vary", "Accept-Language")
+		return userView{ID: params.ID, Name: "user01"}, nil
+	}, renderUserView)
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, "<div>user01</div>", h.writer.Body.String())
+	assert.Equal(t, http.Header{
+		"Content-Type": {"text/html; charset=utf-8"},
+		"Vary":         {"Accept-Language", "Accept"},
+	}, h.writer.Header())
+}
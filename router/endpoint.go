@@ -0,0 +1,179 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+// -------------------------------------------------------------------------
+// Internal Implementation
+// -------------------------------------------------------------------------
+
+type endpointConfig[T any] struct {
+	method  string
+	urlPath urls.Path[T]
+
+	bindParams    func(req *http.Request, params *T) error
+	handler       GenericHandler
+	writeResponse func(ctx Context, resp any) error
+	handleError   func(ctx Context, err error)
+}
+
+func registerEndpoint[T any](router *Router, conf endpointConfig[T]) {
+	for _, fn := range router.paramValidators {
+		var empty T
+		fn(empty)
+	}
+
+	method := conf.method
+	pattern := conf.urlPath.GetPattern()
+
+	// check duplicate endpoint
+	key := endpointKey{
+		method:  method,
+		pattern: pattern,
+	}
+	_, existed := router.state.registered[key]
+	if existed {
+		panic(fmt.Sprintf("%s %s is already defined", method, pattern))
+	}
+
+	// check satisfying url prefix
+	if !strings.HasPrefix(pattern, router.urlPrefix) {
+		panic(fmt.Sprintf(
+			"%s %s not satisfy url prefix '%s'",
+			method, pattern, router.urlPrefix,
+		))
+	}
+
+	// record route info
+	router.state.registered[key] = len(router.state.routes)
+	router.state.routes = append(router.state.routes, RouteInfo{
+		Method:  method,
+		Pattern: pattern,
+
+		ParamsType:    reflect.TypeFor[T](),
+		PathParams:    conf.urlPath.GetPathParams(),
+		NonPathParams: conf.urlPath.GetNonPathParams(),
+
+		Middlewares: router.getMiddlewareNames(),
+	})
+
+	// setup middlewares
+	genericHandler := router.applyMiddlewares(conf.handler)
+
+	stdHandlerError := func(ctx Context) error {
+		var params T
+		if err := conf.bindParams(ctx.Request, &params); err != nil {
+			return err
+		}
+
+		// path params are bound last, so they can not be overridden by request body
+		err := urls.SetStructWithValues(&params, conf.urlPath.GetPathParams(), func(name string) string {
+			return chi.URLParam(ctx.Request, name)
+		})
+		if err != nil {
+			return &HtmlError{
+				Reason:  ReasonBadPathParam,
+				Message: err.Error(),
+			}
+		}
+
+		// validate params, returns *urls.ValidationError
+		if err := urls.Validate(&params); err != nil {
+			return err
+		}
+
+		// call handler
+		resp, err := genericHandler(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if resp == nil {
+			return nil
+		}
+
+		if ctx.state.responded {
+			return nil
+		}
+		return conf.writeResponse(ctx, resp)
+	}
+
+	router.state.chi.MethodFunc(method, pattern, func(writer http.ResponseWriter, req *http.Request) {
+		defer removeMultipartFiles(req)
+
+		ctx := NewContext(writer, req)
+		if router.state.recovery {
+			defer recoverEndpoint(ctx, conf.handleError)
+		}
+
+		if err := stdHandlerError(ctx); err != nil {
+			conf.handleError(ctx, err)
+			return
+		}
+
+		// write status and pending headers in case of empty response body
+		ctx.writer.WriteHeader(ctx.state.getStatus())
+	})
+}
+
+// bindFormParams binds non path params using form values and url query,
+// files are bound for multipart/form-data requests
+func bindFormParams[T any](
+	urlPath urls.Path[T], multipartConf multipartConfig,
+) func(req *http.Request, params *T) error {
+	return func(req *http.Request, params *T) error {
+		isMultipart := isMultipartRequest(req)
+		if isMultipart {
+			if err := parseMultipartForm(req, multipartConf); err != nil {
+				return err
+			}
+		}
+
+		if !isMultipart {
+			if err := req.ParseForm(); err != nil {
+				return &HtmlError{
+					Reason:  ReasonBadFormParam,
+					Message: err.Error(),
+				}
+			}
+		}
+
+		err := urls.SetStructWithValueList(params, urlPath.GetNonPathParams(), func(name string) []string {
+			return req.Form[name]
+		})
+		if err != nil {
+			return &HtmlError{
+				Reason:  ReasonBadFormParam,
+				Message: err.Error(),
+			}
+		}
+
+		if isMultipart {
+			return bindMultipartFiles(req, urlPath, params)
+		}
+		return nil
+	}
+}
+
+func (r *Router) applyMiddlewares(handler GenericHandler) GenericHandler {
+	// setup final hooks
+	for _, hook := range slices.Backward(r.state.finalHooks) {
+		handler = hook(handler)
+	}
+
+	// setup normal middlewares
+	for _, mw := range slices.Backward(r.middlewares) {
+		handler = mw(handler)
+	}
+
+	return handler
+}
@@ -2,7 +2,14 @@ package router
 
 import (
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
+	"strconv"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/null"
+	"github.com/QuangTung97/weblib/urls"
 )
 
 type HtmlErrorReason int
@@ -11,6 +18,7 @@ const (
 	ReasonBadPathParam HtmlErrorReason = iota + 1
 	ReasonBadFormParam
 	ReasonBadResponseType
+	ReasonBadJsonBody
 )
 
 type HtmlError struct {
@@ -22,21 +30,209 @@ func (e *HtmlError) Error() string {
 	return e.Message
 }
 
+// HttpError is an error with a http status code that handlers can return.
+// Message is shown to the client, Cause is only used for logging.
+// Body is an optional html element, rendered instead of the default error page
+type HttpError struct {
+	Status  int
+	Message string
+	Cause   error
+	Body    null.Null[hx.Elem]
+}
+
+func NewHttpError(status int, message string) *HttpError {
+	return &HttpError{
+		Status:  status,
+		Message: message,
+	}
+}
+
+func BadRequest(message string) *HttpError {
+	return NewHttpError(http.StatusBadRequest, message)
+}
+
+func Unauthorized(message string) *HttpError {
+	return NewHttpError(http.StatusUnauthorized, message)
+}
+
+func Forbidden(message string) *HttpError {
+	return NewHttpError(http.StatusForbidden, message)
+}
+
+func NotFound(message string) *HttpError {
+	return NewHttpError(http.StatusNotFound, message)
+}
+
+func Conflict(message string) *HttpError {
+	return NewHttpError(http.StatusConflict, message)
+}
+
+// InternalError hides the cause from the client
+func InternalError(cause error) *HttpError {
+	return NewHttpError(
+		http.StatusInternalServerError,
+		http.StatusText(http.StatusInternalServerError),
+	).WithCause(cause)
+}
+
+// WithCause returns a copy of the error with the internal cause
+func (e *HttpError) WithCause(cause error) *HttpError {
+	newErr := *e
+	newErr.Cause = cause
+	return &newErr
+}
+
+// WithBody returns a copy of the error with the custom html body
+func (e *HttpError) WithBody(body hx.Elem) *HttpError {
+	newErr := *e
+	newErr.Body = null.New(body)
+	return &newErr
+}
+
+func (e *HttpError) Error() string {
+	if e.Cause == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Cause.Error()
+}
+
+func (e *HttpError) Unwrap() error {
+	return e.Cause
+}
+
 func (r *Router) SetCustomHtmlErrorHandler(handler func(ctx Context, err error)) {
 	r.state.handleHtmlError = handler
 }
 
+// DefaultHtmlErrorHandler renders *HttpError as html with its status code,
+// a fragment is rendered instead of a full page for htmx requests.
+// By default htmx does not swap 4xx and 5xx responses, so to show the fragment
+// the client must enable swapping of error responses using htmx.config.responseHandling, e.g.
+//
+//	htmx.config.responseHandling = [
+//		{code: "204", swap: false},
+//		{code: "[23]..", swap: true},
+//		{code: "[45]..", swap: true, error: true},
+//	]
+//
+// The target of the fragment can be changed in handlers using Context.HxRetarget and Context.HxReswap.
+// *urls.ValidationError is rendered as a list of field errors with status 422.
+// *HtmlError of binding params is rendered with status 400.
+// Other errors are rendered as InternalError with status 500, the cause is only logged
 func (r *Router) DefaultHtmlErrorHandler(ctx Context, err error) {
+	var httpErr *HttpError
+	var validationErr *urls.ValidationError
+	var htmlErr *HtmlError
+	if errors.As(err, &validationErr) {
+		httpErr = validationHttpError(validationErr)
+	} else if errors.As(err, &httpErr) {
+		// use the http error as it is
+	} else if errors.As(err, &htmlErr) {
+		httpErr = BadRequest(htmlErr.Message)
+	} else {
+		httpErr = InternalError(err)
+	}
+
+	logHttpError(httpErr)
+
+	body := httpErr.Body.Data
+	if !httpErr.Body.Valid {
+		body = defaultErrorBody(ctx, httpErr)
+	}
+
+	writer := ctx.GetWriter()
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.WriteHeader(httpErr.Status)
+	_ = body.Render(writer)
+}
+
+func (r *Router) SetCustomJsonErrorHandler(handler func(ctx Context, err error)) {
+	r.state.handleJsonError = handler
+}
+
+// DefaultJsonErrorHandler uses status code and message of *HttpError,
+// *urls.ValidationError is responded with status 422 and a map of field errors,
+// *HtmlError of binding params is responded with status 400.
+// Other errors are responded as InternalError with status 500, the cause is only logged
+func (r *Router) DefaultJsonErrorHandler(ctx Context, err error) {
 	type errorMessage struct {
-		Error string `json:"error"`
+		Error  string            `json:"error"`
+		Fields map[string]string `json:"fields,omitempty"`
+	}
+
+	var fields map[string]string
+
+	var httpErr *HttpError
+	var validationErr *urls.ValidationError
+	var htmlErr *HtmlError
+	if errors.As(err, &httpErr) {
+		// use the http error as it is
+	} else if errors.As(err, &validationErr) {
+		httpErr = NewHttpError(http.StatusUnprocessableEntity, err.Error())
+		fields = validationErr.ToMap()
+	} else if errors.As(err, &htmlErr) {
+		httpErr = BadRequest(htmlErr.Message)
+	} else {
+		httpErr = InternalError(err)
 	}
 
+	logHttpError(httpErr)
+	status := httpErr.Status
+	message := httpErr.Message
+
 	writer := ctx.GetWriter()
-	writer.WriteHeader(http.StatusBadRequest)
 	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
 
 	enc := json.NewEncoder(writer)
 	_ = enc.Encode(errorMessage{
-		Error: err.Error(),
+		Error:  message,
+		Fields: fields,
 	})
 }
+
+func logHttpError(err *HttpError) {
+	if err.Status < http.StatusInternalServerError {
+		return
+	}
+	slog.Error("http handler error", "status", err.Status, "error", err.Error())
+}
+
+// defaultErrorBody renders only the fragment for htmx requests,
+// it is swapped only if the client configures htmx.config.responseHandling for error responses
+func defaultErrorBody(ctx Context, err *HttpError) hx.Elem {
+	statusText := strconv.Itoa(err.Status) + " " + http.StatusText(err.Status)
+
+	fragment := hx.Div(
+		hx.Class("http-error"),
+		hx.Role("alert"),
+		hx.H1(hx.Text(statusText)),
+		hx.P(hx.Text(err.Message)),
+	)
+
+	if ctx.IsHxRequest() {
+		return fragment
+	}
+	return hx.Html(statusText, hx.None(), fragment)
+}
+
+func validationHttpError(err *urls.ValidationError) *HttpError {
+	items := make([]hx.Elem, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		message := field.Message
+		if len(field.Field) > 0 {
+			message = field.Field + ": " + message
+		}
+		items = append(items, hx.Li(
+			hx.DataAttr("field", field.Field),
+			hx.Text(message),
+		))
+	}
+
+	httpErr := NewHttpError(http.StatusUnprocessableEntity, "invalid params").WithCause(err)
+	return httpErr.WithBody(hx.Div(
+		hx.Class("validation-error"),
+		hx.Role("alert"),
+		hx.Ul(items...),
+	))
+}
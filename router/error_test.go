@@ -0,0 +1,120 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func TestHttpError(t *testing.T) {
+	t.Run("without cause", func(t *testing.T) {
+		err := NotFound("user not found")
+		assert.Equal(t, "user not found", err.Error())
+		assert.Equal(t, http.StatusNotFound, err.Status)
+		assert.Equal(t, nil, errors.Unwrap(err))
+	})
+
+	t.Run("with cause", func(t *testing.T) {
+		cause := errors.New("db timeout")
+		err := InternalError(cause)
+		assert.Equal(t, "Internal Server Error: db timeout", err.Error())
+		assert.Equal(t, http.StatusInternalServerError, err.Status)
+		assert.Equal(t, true, errors.Is(err, cause))
+	})
+
+	t.Run("with cause, do not change the original", func(t *testing.T) {
+		origin := Conflict("version conflict")
+		err := origin.WithCause(errors.New("row changed"))
+		assert.Equal(t, "version conflict", origin.Error())
+		assert.Equal(t, "version conflict: row changed", err.Error())
+	})
+}
+
+func TestHtmlGet__Http_Error(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return hx.None(), fmt.Errorf("find user: %w", NotFound("user not found"))
+	})
+
+	h.doGet("/users/123")
+
+	// check output
+	assert.Equal(t, http.StatusNotFound, h.writer.Code)
+	assert.Equal(t,
+		`<!DOCTYPE html><html lang="en"><head><title>404 Not Found</title>`+
+			`<meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"></head>`+
+			`<body><div class="http-error" role="alert"><h1>404 Not Found</h1><p>user not found</p></div></body></html>`,
+		h.writer.Body.String(),
+	)
+
+	// check headers
+	assert.Equal(t, http.Header{
+		"Content-Type": {"text/html; charset=utf-8"},
+	}, h.writer.Header())
+}
+
+func TestHtmlGet__Http_Error__Hx_Request(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return hx.None(), Forbidden("not allowed")
+	})
+
+	h.req = newHxRequest(http.MethodGet, "/users/123")
+	h.serve()
+
+	// check output
+	assert.Equal(t, http.StatusForbidden, h.writer.Code)
+	assert.Equal(t,
+		`<div class="http-error" role="alert"><h1>403 Forbidden</h1><p>not allowed</p></div>`,
+		h.writer.Body.String(),
+	)
+}
+
+func TestHtmlGet__Http_Error__With_Body(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		err := InternalError(errors.New("db error")).WithBody(
+			hx.Div(hx.Text("Please try again")),
+		)
+		return hx.None(), err
+	})
+
+	h.doGet("/users/123")
+
+	// check output
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t, `<div>Please try again</div>`, h.writer.Body.String())
+}
+
+func TestJsonGet__Http_Error(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+	JsonGet(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		return jsonResponse{}, InternalError(errors.New("db error"))
+	})
+
+	h.doGet("/api/users/123")
+
+	// check output, the internal cause is hidden
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t, `{"error":"Internal Server Error"}`+"\n", h.writer.Body.String())
+}
+
+func htmlErrorPage(statusText string, message string) string {
+	return `<!DOCTYPE html><html lang="en"><head><title>` + statusText + `</title>` +
+		`<meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"></head>` +
+		`<body><div class="http-error" role="alert"><h1>` + statusText + `</h1><p>` + message + `</p></div></body></html>`
+}
@@ -3,7 +3,6 @@ package router
 import (
 	"fmt"
 	"net/http"
-	"slices"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
@@ -48,113 +47,64 @@ func HtmlDelete[T any](
 	htmlMethod(router, http.MethodDelete, urlPath, handler)
 }
 
-func htmlMethod[T any](
+// HtmlPatch set up Patch handler
+func HtmlPatch[T any](
 	router *Router,
-	method string,
 	urlPath urls.Path[T],
 	handler func(ctx Context, params T) (hx.Elem, error),
 ) {
-	for _, fn := range router.paramValidators {
-		var empty T
-		fn(empty)
-	}
-
-	pattern := urlPath.GetPattern()
-
-	// check duplicate endpoint
-	key := endpointKey{
-		method:  method,
-		pattern: pattern,
-	}
-	_, existed := router.state.registered[key]
-	if existed {
-		panic(fmt.Sprintf("%s %s is already defined", method, pattern))
-	}
-	router.state.registered[key] = struct{}{}
+	htmlMethod(router, http.MethodPatch, urlPath, handler)
+}
 
-	// check satisfying url prefix
-	if !strings.HasPrefix(pattern, router.urlPrefix) {
-		panic(fmt.Sprintf(
-			"%s %s not satisfy url prefix '%s'",
-			method, pattern, router.urlPrefix,
-		))
+// HtmlHandle set up handler for an arbitrary http method.
+// Non-standard methods are registered to chi before setting up the handler
+func HtmlHandle[T any](
+	router *Router,
+	method string,
+	urlPath urls.Path[T],
+	handler func(ctx Context, params T) (hx.Elem, error),
+) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if len(method) == 0 {
+		panic(fmt.Sprintf("missing http method for pattern %s", urlPath.GetPattern()))
 	}
 
-	// setup middlewares
-	genericHandler := func(ctx Context, req any) (any, error) {
-		resp, err := handler(ctx, req.(T))
-		return resp, err
+	if !isStandardMethod(method) {
+		chi.RegisterMethod(method)
 	}
-	genericHandler = router.applyMiddlewares(genericHandler)
-
-	stdHandlerError := func(writer http.ResponseWriter, req *http.Request) error {
-		var params T
-		err := urls.SetStructWithValues(&params, urlPath.GetPathParams(), func(name string) string {
-			return chi.URLParam(req, name)
-		})
-		if err != nil {
-			return &HtmlError{
-				Reason:  ReasonBadPathParam,
-				Message: err.Error(),
-			}
-		}
-
-		err = urls.SetStructWithValues(&params, urlPath.GetNonPathParams(), func(name string) string {
-			return req.FormValue(name)
-		})
-		if err != nil {
-			return &HtmlError{
-				Reason:  ReasonBadFormParam,
-				Message: err.Error(),
-			}
-		}
-
-		// call handler
-		ctx := NewContext(writer, req)
-		resp, err := genericHandler(ctx, params)
-		if err != nil {
-			return err
-		}
 
-		if resp == nil {
-			return nil
-		}
-
-		outputElem, ok := resp.(hx.Elem)
-		if !ok {
-			err := fmt.Errorf("failed to convert response to hx.Elem")
-			return &HtmlError{
-				Reason:  ReasonBadResponseType,
-				Message: err.Error(),
-			}
-		}
+	htmlMethod(router, method, urlPath, handler)
+}
 
-		if !ctx.state.responded {
-			ctx.state.responded = true
-			writer.Header().Set("Content-Type", "text/html; charset=utf-8")
-			_ = outputElem.Render(writer)
-		}
-		return nil
+func isStandardMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost,
+		http.MethodPut, http.MethodPatch, http.MethodDelete,
+		http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
 	}
+}
 
-	router.state.chi.MethodFunc(method, urlPath.GetPattern(), func(writer http.ResponseWriter, req *http.Request) {
-		if err := stdHandlerError(writer, req); err != nil {
-			ctx := NewContext(writer, req)
+func htmlMethod[T any](
+	router *Router,
+	method string,
+	urlPath urls.Path[T],
+	handler func(ctx Context, params T) (hx.Elem, error),
+) {
+	registerEndpoint(router, endpointConfig[T]{
+		method:  method,
+		urlPath: urlPath,
+
+		bindParams: bindFormParams(urlPath, router.multipart),
+		handler: func(ctx Context, req any) (any, error) {
+			resp, err := handler(ctx, req.(T))
+			return resp, err
+		},
+		writeResponse: router.newHtmlResponseWriter(),
+		handleError: func(ctx Context, err error) {
 			router.state.handleHtmlError(ctx, err)
-		}
+		},
 	})
 }
-
-func (r *Router) applyMiddlewares(handler GenericHandler) GenericHandler {
-	// setup final hooks
-	for _, hook := range slices.Backward(r.state.finalHooks) {
-		handler = hook(handler)
-	}
-
-	// setup normal middlewares
-	for _, mw := range slices.Backward(r.middlewares) {
-		handler = mw(handler)
-	}
-
-	return handler
-}
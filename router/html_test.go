@@ -95,6 +95,17 @@ func (h *htmlTest) doMethod(method string, postURL string, data url.Values) {
 	h.router.GetChi().ServeHTTP(h.writer, h.req)
 }
 
+func (h *htmlTest) serve() {
+	h.writer = httptest.NewRecorder()
+	h.router.GetChi().ServeHTTP(h.writer, h.req)
+}
+
+func newHxRequest(method string, reqURL string) *http.Request {
+	req := httptest.NewRequest(method, reqURL, nil)
+	req.Header.Set("Hx-Request", "true")
+	return req
+}
+
 type htmlParams struct {
 	ID     int    `json:"id"`
 	Search string `json:"search"`
@@ -170,13 +181,13 @@ func TestHtmlGet__Can_Not_Parse_Path_Param(t *testing.T) {
 	// check output
 	assert.Equal(t, 400, h.writer.Code)
 	assert.Equal(t,
-		`{"error":"can not set value 'invalid' to field 'id' with type 'int'"}`+"\n",
+		htmlErrorPage("400 Bad Request", "can not set value &#39;invalid&#39; to field &#39;id&#39; with type &#39;int&#39;"),
 		h.writer.Body.String(),
 	)
 
 	// check headers
 	assert.Equal(t, http.Header{
-		"Content-Type": []string{"application/json"},
+		"Content-Type": []string{"text/html; charset=utf-8"},
 	}, h.writer.Header())
 
 	// check actions
@@ -205,7 +216,7 @@ func TestHtmlGet__Can_Not_Parse_Query_Param(t *testing.T) {
 	// check output
 	assert.Equal(t, 400, h.writer.Code)
 	assert.Equal(t,
-		`{"error":"can not set value 'invalid02' to field 'age' with type 'int64'"}`+"\n",
+		htmlErrorPage("400 Bad Request", "can not set value &#39;invalid02&#39; to field &#39;age&#39; with type &#39;int64&#39;"),
 		h.writer.Body.String(),
 	)
 }
@@ -229,10 +240,10 @@ func TestHtmlGet__Handler_Error(t *testing.T) {
 		{ID: 123, Age: 81},
 	}, inputParams)
 
-	// check output
-	assert.Equal(t, 400, h.writer.Code)
+	// check output, the cause is hidden from the client
+	assert.Equal(t, 500, h.writer.Code)
 	assert.Equal(t,
-		`{"error":"handler error"}`+"\n",
+		htmlErrorPage("500 Internal Server Error", "Internal Server Error"),
 		h.writer.Body.String(),
 	)
 }
@@ -495,3 +506,139 @@ func TestHtmlGet__Normal__Middleware__Redirect(t *testing.T) {
 		"middleware01",
 	}, h.actions)
 }
+
+func TestHtmlPatch__Normal(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+
+	var inputParams []htmlParams
+	HtmlPatch(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+		return hx.Div(hx.Text("patched")), nil
+	})
+
+	h.doMethod(http.MethodPatch, "/users/123", url.Values{
+		"search": {"hello03"},
+	})
+
+	// check input
+	assert.Equal(t, []htmlParams{
+		{ID: 123, Search: "hello03"},
+	}, inputParams)
+
+	// check output
+	assert.Equal(t, 200, h.writer.Code)
+	assert.Equal(t, "<div>patched</div>", h.writer.Body.String())
+}
+
+func TestHtmlHandle__Custom_Method(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+
+	var inputParams []htmlParams
+	HtmlHandle(h.router, "purge", urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+		return hx.None(), nil
+	})
+	HtmlHandle(h.router, http.MethodOptions, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return hx.None(), nil
+	})
+
+	h.doMethod("PURGE", "/users/123?age=41", nil)
+
+	// check input
+	assert.Equal(t, []htmlParams{
+		{ID: 123, Age: 41},
+	}, inputParams)
+	assert.Equal(t, 200, h.writer.Code)
+
+	// check duplicated
+	assert.PanicsWithValue(t, "PURGE /users/{id} is already defined", func() {
+		HtmlHandle(h.router, "Purge", urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+			return hx.None(), nil
+		})
+	})
+
+	// check empty method
+	assert.PanicsWithValue(t, "missing http method for pattern /users/{id}", func() {
+		HtmlHandle(h.router, " ", urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+			return hx.None(), nil
+		})
+	})
+}
+
+type filterParams struct {
+	Tags  []string `json:"tag"`
+	Users []int64  `json:"user"`
+}
+
+func TestHtmlPost__Slice_Params(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[filterParams]("/users")
+
+	var inputParams []filterParams
+	HtmlPost(h.router, urlPath, func(ctx Context, params filterParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+		return hx.None(), nil
+	})
+
+	h.doMethod(http.MethodPost, "/users?tag=a&tag=b", url.Values{
+		"user": {"11", "12"},
+		"tag":  {"c"},
+	})
+
+	// check input
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, []filterParams{
+		{Tags: []string{"c", "a", "b"}, Users: []int64{11, 12}},
+	}, inputParams)
+}
+
+func TestHtmlGet__Invalid_Query_Escape(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[filterParams]("/users")
+	HtmlGet(h.router, urlPath, func(ctx Context, params filterParams) (hx.Elem, error) {
+		return hx.None(), nil
+	})
+
+	h.doGet("/users?tag=%zz")
+
+	assert.Equal(t, http.StatusBadRequest, h.writer.Code)
+	assert.Equal(t, htmlErrorPage("400 Bad Request", "invalid URL escape &#34;%zz&#34;"), h.writer.Body.String())
+}
+
+type pageParams struct {
+	Page int `json:"page"`
+}
+
+type nestedFilterParams struct {
+	pageParams
+	Filter filterParams `json:"filter"`
+}
+
+func TestHtmlGet__Nested_Params(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[nestedFilterParams]("/users")
+
+	var inputParams []nestedFilterParams
+	HtmlGet(h.router, urlPath, func(ctx Context, params nestedFilterParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+		return hx.None(), nil
+	})
+
+	h.doGet("/users?page=2&filter.tag=a&filter%5Buser%5D=11")
+
+	// check input
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, []nestedFilterParams{
+		{
+			pageParams: pageParams{Page: 2},
+			Filter:     filterParams{Tags: []string{"a"}, Users: []int64{11}},
+		},
+	}, inputParams)
+}
@@ -0,0 +1,208 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/QuangTung97/weblib/hx"
+)
+
+const (
+	hxRequestHeader                = "HX-Request"
+	hxRequestBoosted               = "HX-Boosted"
+	hxRequestTarget                = "HX-Target"
+	hxRequestTrigger               = "HX-Trigger"
+	hxRequestTriggerName           = "HX-Trigger-Name"
+	hxRequestCurrentURL            = "HX-Current-URL"
+	hxRequestPrompt                = "HX-Prompt"
+	hxRequestHistoryRestoreRequest = "HX-History-Restore-Request"
+)
+
+const (
+	hxHeaderRedirect   = "HX-Redirect"
+	hxHeaderLocation   = "HX-Location"
+	hxHeaderPushUrl    = "HX-Push-Url"
+	hxHeaderReplaceUrl = "HX-Replace-Url"
+	hxHeaderRefresh    = "HX-Refresh"
+	hxHeaderRetarget   = "HX-Retarget"
+	hxHeaderReswap     = "HX-Reswap"
+	hxHeaderReselect   = "HX-Reselect"
+
+	hxHeaderTrigger            = "HX-Trigger"
+	hxHeaderTriggerAfterSwap   = "HX-Trigger-After-Swap"
+	hxHeaderTriggerAfterSettle = "HX-Trigger-After-Settle"
+)
+
+// HxRequestInfo contains the request headers sent by htmx
+type HxRequestInfo struct {
+	Request bool // always true for requests made by htmx
+	Boosted bool // the request is via an element using hx-boost
+
+	Target      string // the id of the target element if it exists
+	Trigger     string // the id of the triggered element if it exists
+	TriggerName string // the name of the triggered element if it exists
+	CurrentURL  string // the current url of the browser
+	Prompt      string // the user response to an hx-prompt
+
+	HistoryRestoreRequest bool // the request is for history restoration after a miss in the local history cache
+}
+
+// IsPartial returns true when the response will be swapped into a part of the current page.
+// Normal requests, boosted requests and history restore requests need a full page
+func (i HxRequestInfo) IsPartial() bool {
+	return i.Request && !i.Boosted && !i.HistoryRestoreRequest
+}
+
+// HxInfo returns the htmx request headers
+func (c Context) HxInfo() HxRequestInfo {
+	header := c.Request.Header
+	return HxRequestInfo{
+		Request: header.Get(hxRequestHeader) == "true",
+		Boosted: header.Get(hxRequestBoosted) == "true",
+
+		Target:      header.Get(hxRequestTarget),
+		Trigger:     header.Get(hxRequestTrigger),
+		TriggerName: header.Get(hxRequestTriggerName),
+		CurrentURL:  header.Get(hxRequestCurrentURL),
+		Prompt:      header.Get(hxRequestPrompt),
+
+		HistoryRestoreRequest: header.Get(hxRequestHistoryRestoreRequest) == "true",
+	}
+}
+
+// PageOrPartial chooses between the full page rendering and the partial rendering
+// based on HxRequestInfo.IsPartial. The Vary header is set so that caches keep both versions
+func PageOrPartial(ctx Context, fullPage func() hx.Elem, partial func() hx.Elem) hx.Elem {
+	ctx.writer.Header().Add("Vary", hxRequestHeader)
+
+	if ctx.HxInfo().IsPartial() {
+		return partial()
+	}
+	return fullPage()
+}
+
+// HxRedirect does a client-side redirect to a new location (full page reload).
+// The response body is not rendered after calling this method
+func (c Context) HxRedirect(redirectURL string) {
+	c.state.responded = true
+	c.writer.Header().Set(hxHeaderRedirect, redirectURL)
+}
+
+// HxLocation does a client-side redirect without a full page reload
+func (c Context) HxLocation(location string) {
+	c.writer.Header().Set(hxHeaderLocation, location)
+}
+
+// HxPushUrl pushes a new url into the history stack
+func (c Context) HxPushUrl(pushURL string) {
+	c.writer.Header().Set(hxHeaderPushUrl, pushURL)
+}
+
+// HxPreventPushUrl prevents the browser history from being updated
+func (c Context) HxPreventPushUrl() {
+	c.writer.Header().Set(hxHeaderPushUrl, "false")
+}
+
+// HxReplaceUrl replaces the current url in the location bar
+func (c Context) HxReplaceUrl(replaceURL string) {
+	c.writer.Header().Set(hxHeaderReplaceUrl, replaceURL)
+}
+
+// HxRefresh makes the client do a full refresh of the page
+func (c Context) HxRefresh() {
+	c.writer.Header().Set(hxHeaderRefresh, "true")
+}
+
+// HxRetarget updates the target of the content update to a different element, using a css selector
+func (c Context) HxRetarget(selector string) {
+	c.writer.Header().Set(hxHeaderRetarget, selector)
+}
+
+// HxReswap changes the swap strategy, e.g. "outerHTML" or "innerHTML show:top"
+func (c Context) HxReswap(swap string) {
+	c.writer.Header().Set(hxHeaderReswap, swap)
+}
+
+// HxReselect chooses which part of the response is used to be swapped in, using a css selector
+func (c Context) HxReselect(selector string) {
+	c.writer.Header().Set(hxHeaderReselect, selector)
+}
+
+// HxTrigger triggers a client-side event as soon as the response is received.
+// The payload is json encoded as the event detail, can be nil.
+// Calling multiple times will trigger multiple events.
+// An error is returned and the event is not added if the payload can not be json encoded
+func (c Context) HxTrigger(event string, payload any) error {
+	return c.addHxTrigger(hxHeaderTrigger, event, payload)
+}
+
+// HxTriggerAfterSwap is similar to HxTrigger, but triggers after the swap step
+func (c Context) HxTriggerAfterSwap(event string, payload any) error {
+	return c.addHxTrigger(hxHeaderTriggerAfterSwap, event, payload)
+}
+
+// HxTriggerAfterSettle is similar to HxTrigger, but triggers after the settle step
+func (c Context) HxTriggerAfterSettle(event string, payload any) error {
+	return c.addHxTrigger(hxHeaderTriggerAfterSettle, event, payload)
+}
+
+// -------------------------------------------------------------------------
+// Internal Implementation
+// -------------------------------------------------------------------------
+
+type hxTriggerEvent struct {
+	name    string
+	payload json.RawMessage // nil for events without payload
+}
+
+func (c Context) addHxTrigger(header string, event string, payload any) error {
+	var rawPayload json.RawMessage
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("encode payload of hx trigger event '%s': %w", event, err)
+		}
+		rawPayload = data
+	}
+
+	if c.state.hxTriggers == nil {
+		c.state.hxTriggers = map[string][]hxTriggerEvent{}
+	}
+
+	events := c.state.hxTriggers[header]
+	events = append(events, hxTriggerEvent{
+		name:    event,
+		payload: rawPayload,
+	})
+	c.state.hxTriggers[header] = events
+
+	c.writer.Header().Set(header, encodeHxTriggerEvents(events))
+	return nil
+}
+
+func encodeHxTriggerEvents(events []hxTriggerEvent) string {
+	hasPayload := false
+	for _, e := range events {
+		if e.payload != nil {
+			hasPayload = true
+		}
+	}
+
+	if !hasPayload {
+		names := make([]string, 0, len(events))
+		for _, e := range events {
+			names = append(names, e.name)
+		}
+		return strings.Join(names, ", ")
+	}
+
+	// payloads are already encoded, so encoding can not fail
+	obj := map[string]json.RawMessage{}
+	for _, e := range events {
+		obj[e.name] = e.payload
+	}
+
+	data, _ := json.Marshal(obj)
+	return string(data)
+}
@@ -0,0 +1,204 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func TestContext_HxResponseHeaders(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlPost(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		ctx.HxLocation("/users")
+		ctx.HxPushUrl("/users/123")
+		ctx.HxReplaceUrl("/users/124")
+		ctx.HxRefresh()
+		ctx.HxRetarget("#main")
+		ctx.HxReswap("outerHTML")
+		ctx.HxReselect("#content")
+		return hx.Div(hx.Text("Hello")), nil
+	})
+
+	h.req = newHxRequest(http.MethodPost, "/users/123")
+	h.serve()
+
+	assert.Equal(t, 200, h.writer.Code)
+	assert.Equal(t, "<div>Hello</div>", h.writer.Body.String())
+	assert.Equal(t, http.Header{
+		"Content-Type":   {"text/html; charset=utf-8"},
+		"Hx-Location":    {"/users"},
+		"Hx-Push-Url":    {"/users/123"},
+		"Hx-Replace-Url": {"/users/124"},
+		"Hx-Refresh":     {"true"},
+		"Hx-Retarget":    {"#main"},
+		"Hx-Reswap":      {"outerHTML"},
+		"Hx-Reselect":    {"#content"},
+	}, h.writer.Header())
+}
+
+func TestContext_HxTrigger(t *testing.T) {
+	t.Run("without payload", func(t *testing.T) {
+		h := newHtmlTest()
+
+		urlPath := urls.New[htmlParams]("/users/{id}")
+		HtmlPost(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+			ctx.HxTrigger("userUpdated", nil)
+			ctx.HxTrigger("listChanged", nil)
+			ctx.HxTriggerAfterSwap("swapped", nil)
+			return hx.None(), nil
+		})
+
+		h.req = newHxRequest(http.MethodPost, "/users/123")
+		h.serve()
+
+		assert.Equal(t, "userUpdated, listChanged", h.writer.Header().Get("HX-Trigger"))
+		assert.Equal(t, "swapped", h.writer.Header().Get("HX-Trigger-After-Swap"))
+	})
+
+	t.Run("with payload", func(t *testing.T) {
+		h := newHtmlTest()
+
+		urlPath := urls.New[htmlParams]("/users/{id}")
+		HtmlPost(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+			ctx.HxTriggerAfterSettle("showMessage", map[string]string{
+				"level": "info",
+			})
+			ctx.HxTriggerAfterSettle("closeModal", nil)
+			return hx.None(), nil
+		})
+
+		h.req = newHxRequest(http.MethodPost, "/users/123")
+		h.serve()
+
+		assert.Equal(t,
+			`{"closeModal":null,"showMessage":{"level":"info"}}`,
+			h.writer.Header().Get("HX-Trigger-After-Settle"),
+		)
+	})
+
+	t.Run("payload can not be encoded", func(t *testing.T) {
+		h := newHtmlTest()
+
+		var triggerErr error
+		urlPath := urls.New[htmlParams]("/users/{id}")
+		HtmlPost(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+			_ = ctx.HxTrigger("userUpdated", nil)
+			triggerErr = ctx.HxTrigger("invalid", make(chan int))
+			return hx.None(), nil
+		})
+
+		h.req = newHxRequest(http.MethodPost, "/users/123")
+		h.serve()
+
+		assert.Equal(t, http.StatusOK, h.writer.Code)
+		assert.Equal(t,
+			"encode payload of hx trigger event 'invalid': json: unsupported type: chan int",
+			triggerErr.Error(),
+		)
+		assert.Equal(t, "userUpdated", h.writer.Header().Get("HX-Trigger"))
+	})
+}
+
+func TestContext_HttpRedirect__Hx_Request(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		ctx.HttpRedirect("/login")
+		return hx.Div(), nil
+	})
+
+	h.req = newHxRequest(http.MethodGet, "/users/123")
+	h.serve()
+
+	// check output
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, "", h.writer.Body.String())
+
+	// check headers
+	assert.Equal(t, http.Header{
+		"Hx-Redirect": {"/login"},
+	}, h.writer.Header())
+}
+
+func TestContext_HxInfo(t *testing.T) {
+	t.Run("normal request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		ctx := NewContext(httptest.NewRecorder(), req)
+
+		info := ctx.HxInfo()
+		assert.Equal(t, HxRequestInfo{}, info)
+		assert.Equal(t, false, info.IsPartial())
+	})
+
+	t.Run("full headers", func(t *testing.T) {
+		req := newHxRequest(http.MethodPost, "/users")
+		req.Header.Set("HX-Boosted", "true")
+		req.Header.Set("HX-Target", "main")
+		req.Header.Set("HX-Trigger", "save-btn")
+		req.Header.Set("HX-Trigger-Name", "save")
+		req.Header.Set("HX-Current-URL", "http://localhost/users")
+		req.Header.Set("HX-Prompt", "yes")
+		req.Header.Set("HX-History-Restore-Request", "true")
+		ctx := NewContext(httptest.NewRecorder(), req)
+
+		info := ctx.HxInfo()
+		assert.Equal(t, HxRequestInfo{
+			Request:     true,
+			Boosted:     true,
+			Target:      "main",
+			Trigger:     "save-btn",
+			TriggerName: "save",
+			CurrentURL:  "http://localhost/users",
+			Prompt:      "yes",
+
+			HistoryRestoreRequest: true,
+		}, info)
+		assert.Equal(t, false, info.IsPartial())
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		ctx := NewContext(httptest.NewRecorder(), newHxRequest(http.MethodGet, "/users"))
+		assert.Equal(t, true, ctx.HxInfo().IsPartial())
+	})
+}
+
+func TestPageOrPartial(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return PageOrPartial(
+			ctx,
+			func() hx.Elem {
+				return hx.Div(hx.Text("full"))
+			},
+			func() hx.Elem {
+				return hx.Div(hx.Text("partial"))
+			},
+		), nil
+	})
+
+	// normal request
+	h.doGet("/users/123")
+	assert.Equal(t, "<div>full</div>", h.writer.Body.String())
+	assert.Equal(t, "HX-Request", h.writer.Header().Get("Vary"))
+
+	// htmx request
+	h.req = newHxRequest(http.MethodGet, "/users/123")
+	h.serve()
+	assert.Equal(t, "<div>partial</div>", h.writer.Body.String())
+
+	// boosted request
+	h.req = newHxRequest(http.MethodGet, "/users/123")
+	h.req.Header.Set("HX-Boosted", "true")
+	h.serve()
+	assert.Equal(t, "<div>full</div>", h.writer.Body.String())
+}
@@ -0,0 +1,154 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+// JsonGet set up Get handler with json response
+func JsonGet[Req any, Resp any](
+	router *Router,
+	urlPath urls.Path[Req],
+	handler func(ctx Context, req Req) (Resp, error),
+) {
+	jsonMethod(router, http.MethodGet, urlPath, handler)
+}
+
+// JsonPost set up Post handler with json request body and json response.
+// Fields of Req are also bound from url query, so fields only existing in the json body
+// with types not supported by urls (e.g. slices of structs) must be skipped using the tag url:"-"
+func JsonPost[Req any, Resp any](
+	router *Router,
+	urlPath urls.Path[Req],
+	handler func(ctx Context, req Req) (Resp, error),
+) {
+	jsonMethod(router, http.MethodPost, urlPath, handler)
+}
+
+// JsonPut set up Put handler with json request body and json response, fields of Req are bound like JsonPost
+func JsonPut[Req any, Resp any](
+	router *Router,
+	urlPath urls.Path[Req],
+	handler func(ctx Context, req Req) (Resp, error),
+) {
+	jsonMethod(router, http.MethodPut, urlPath, handler)
+}
+
+// JsonPatch set up Patch handler with json request body and json response, fields of Req are bound like JsonPost
+func JsonPatch[Req any, Resp any](
+	router *Router,
+	urlPath urls.Path[Req],
+	handler func(ctx Context, req Req) (Resp, error),
+) {
+	jsonMethod(router, http.MethodPatch, urlPath, handler)
+}
+
+// JsonDelete set up Delete handler with json response
+func JsonDelete[Req any, Resp any](
+	router *Router,
+	urlPath urls.Path[Req],
+	handler func(ctx Context, req Req) (Resp, error),
+) {
+	jsonMethod(router, http.MethodDelete, urlPath, handler)
+}
+
+func jsonMethod[Req any, Resp any](
+	router *Router,
+	method string,
+	urlPath urls.Path[Req],
+	handler func(ctx Context, req Req) (Resp, error),
+) {
+	registerEndpoint(router, endpointConfig[Req]{
+		method:  method,
+		urlPath: urlPath,
+
+		bindParams: bindJsonParams(urlPath),
+		handler: func(ctx Context, req any) (any, error) {
+			resp, err := handler(ctx, req.(Req))
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		},
+		writeResponse: writeJsonResponse,
+		handleError: func(ctx Context, err error) {
+			router.state.handleJsonError(ctx, err)
+		},
+	})
+}
+
+// bindJsonParams binds non path params using url query, then decodes the json request body if existed.
+// Required params are checked after decoding, so they can be provided by either the query or the body
+func bindJsonParams[T any](urlPath urls.Path[T]) func(req *http.Request, params *T) error {
+	return func(req *http.Request, params *T) error {
+		query := req.URL.Query()
+		err := urls.SetStructWithOptionalValueList(params, urlPath.GetNonPathParams(), func(name string) []string {
+			return query[name]
+		})
+		if err != nil {
+			return &HtmlError{
+				Reason:  ReasonBadFormParam,
+				Message: err.Error(),
+			}
+		}
+
+		if err := decodeJsonBody(req, params); err != nil {
+			return err
+		}
+
+		if err := urls.CheckRequiredParams(params, urlPath.GetNonPathParams()); err != nil {
+			return &HtmlError{
+				Reason:  ReasonBadFormParam,
+				Message: err.Error(),
+			}
+		}
+		return nil
+	}
+}
+
+func decodeJsonBody(req *http.Request, params any) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	dec := json.NewDecoder(req.Body)
+	if err := dec.Decode(params); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return &HtmlError{
+			Reason:  ReasonBadJsonBody,
+			Message: err.Error(),
+		}
+	}
+
+	// only one json value is allowed in the body
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return &HtmlError{
+			Reason:  ReasonBadJsonBody,
+			Message: "invalid trailing data after json body",
+		}
+	}
+	return nil
+}
+
+// writeJsonResponse encodes into a buffer first, so encoding errors can be handled by the json error handler
+func writeJsonResponse(ctx Context, resp any) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(resp); err != nil {
+		return InternalError(fmt.Errorf("failed to encode json response: %w", err))
+	}
+
+	ctx.state.responded = true
+
+	writer := ctx.GetWriter()
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(buf.Bytes())
+	return nil
+}
@@ -0,0 +1,350 @@
+package router
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func (h *htmlTest) doJson(method string, reqURL string, body string) {
+	h.req = httptest.NewRequest(method, reqURL, strings.NewReader(body))
+	h.req.Header.Set("Content-Type", "application/json")
+
+	h.writer = httptest.NewRecorder()
+	h.router.GetChi().ServeHTTP(h.writer, h.req)
+}
+
+type jsonResponse struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+func TestJsonGet__Normal(t *testing.T) {
+	h := newHtmlTest()
+
+	h.addHooks()
+	h.addMiddlewares()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+
+	var inputParams []htmlParams
+	JsonGet(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		h.addAction("handler")
+		inputParams = append(inputParams, params)
+		return jsonResponse{Message: "hello", Count: 12}, nil
+	})
+
+	h.doGet("/api/users/123?search=test01")
+
+	// check input
+	assert.Equal(t, []htmlParams{
+		{ID: 123, Search: "test01"},
+	}, inputParams)
+
+	// check output
+	assert.Equal(t, 200, h.writer.Code)
+	assert.Equal(t, `{"message":"hello","count":12}`+"\n", h.writer.Body.String())
+
+	// check headers
+	assert.Equal(t, http.Header{
+		"Content-Type": []string{"application/json"},
+	}, h.writer.Header())
+
+	// check actions
+	assert.Equal(t, []string{
+		"middleware01",
+		"middleware02",
+		"final-hook-01",
+		"final-hook-02",
+		"handler",
+		"middleware02_end",
+		"middleware01_end",
+	}, h.actions)
+}
+
+func TestJsonPost__Normal(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+
+	var inputParams []htmlParams
+	JsonPost(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		inputParams = append(inputParams, params)
+		return jsonResponse{Message: "created"}, nil
+	})
+
+	h.doJson(http.MethodPost, "/api/users/123?age=31", `{"id":456,"search":"hello02"}`)
+
+	// check input, path param can not be overridden by body
+	assert.Equal(t, []htmlParams{
+		{ID: 123, Search: "hello02", Age: 31},
+	}, inputParams)
+
+	// check output
+	assert.Equal(t, 200, h.writer.Code)
+	assert.Equal(t, `{"message":"created","count":0}`+"\n", h.writer.Body.String())
+}
+
+type jsonRequiredParams struct {
+	ID   int64  `json:"id"`
+	Name string `url:"name,required"`
+}
+
+func TestJsonPost__Required_Param(t *testing.T) {
+	t.Run("from body", func(t *testing.T) {
+		h := newHtmlTest()
+
+		urlPath := urls.New[jsonRequiredParams]("/api/users/{id}")
+
+		var inputParams []jsonRequiredParams
+		JsonPost(h.router, urlPath, func(ctx Context, params jsonRequiredParams) (jsonResponse, error) {
+			inputParams = append(inputParams, params)
+			return jsonResponse{Message: "created"}, nil
+		})
+
+		h.doJson(http.MethodPost, "/api/users/123", `{"Name":"abc"}`)
+
+		assert.Equal(t, []jsonRequiredParams{
+			{ID: 123, Name: "abc"},
+		}, inputParams)
+		assert.Equal(t, 200, h.writer.Code)
+	})
+
+	t.Run("from query", func(t *testing.T) {
+		h := newHtmlTest()
+
+		urlPath := urls.New[jsonRequiredParams]("/api/users/{id}")
+
+		var inputParams []jsonRequiredParams
+		JsonPost(h.router, urlPath, func(ctx Context, params jsonRequiredParams) (jsonResponse, error) {
+			inputParams = append(inputParams, params)
+			return jsonResponse{Message: "created"}, nil
+		})
+
+		h.doJson(http.MethodPost, "/api/users/123?name=hello", `{}`)
+
+		assert.Equal(t, []jsonRequiredParams{
+			{ID: 123, Name: "hello"},
+		}, inputParams)
+		assert.Equal(t, 200, h.writer.Code)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		h := newHtmlTest()
+
+		urlPath := urls.New[jsonRequiredParams]("/api/users/{id}")
+
+		var inputParams []jsonRequiredParams
+		JsonPost(h.router, urlPath, func(ctx Context, params jsonRequiredParams) (jsonResponse, error) {
+			inputParams = append(inputParams, params)
+			return jsonResponse{Message: "created"}, nil
+		})
+
+		h.doJson(http.MethodPost, "/api/users/123", `{"Name":""}`)
+
+		assert.Equal(t, []jsonRequiredParams(nil), inputParams)
+		assert.Equal(t, 400, h.writer.Code)
+		assert.Equal(t, `{"error":"missing required param 'name'"}`+"\n", h.writer.Body.String())
+	})
+}
+
+func TestJsonGet__Encode_Response_Error(t *testing.T) {
+	h := newHtmlTest()
+
+	type floatResponse struct {
+		Value float64 `json:"value"`
+	}
+
+	var handlerErr error
+	h.router.SetCustomJsonErrorHandler(func(ctx Context, err error) {
+		handlerErr = err
+		h.router.DefaultJsonErrorHandler(ctx, err)
+	})
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+	JsonGet(h.router, urlPath, func(ctx Context, params htmlParams) (floatResponse, error) {
+		return floatResponse{Value: math.NaN()}, nil
+	})
+
+	h.doGet("/api/users/123")
+
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t, `{"error":"Internal Server Error"}`+"\n", h.writer.Body.String())
+	assert.Equal(t, http.Header{
+		"Content-Type": []string{"application/json"},
+	}, h.writer.Header())
+	assert.Equal(t,
+		"Internal Server Error: failed to encode json response: json: unsupported value: NaN",
+		handlerErr.Error(),
+	)
+}
+
+type jsonItem struct {
+	Name string `json:"name"`
+}
+
+type jsonBodyParams struct {
+	ID    int64      `json:"id"`
+	Items []jsonItem `json:"items" url:"-"`
+}
+
+func TestJsonPost__Body_Only_Field(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[jsonBodyParams]("/api/users/{id}")
+
+	var inputParams []jsonBodyParams
+	JsonPost(h.router, urlPath, func(ctx Context, params jsonBodyParams) (jsonResponse, error) {
+		inputParams = append(inputParams, params)
+		return jsonResponse{Message: "created"}, nil
+	})
+
+	h.doJson(http.MethodPost, "/api/users/123?items=abc", `{"items":[{"name":"a"},{"name":"b"}]}`)
+
+	assert.Equal(t, []jsonBodyParams{
+		{ID: 123, Items: []jsonItem{{Name: "a"}, {Name: "b"}}},
+	}, inputParams)
+	assert.Equal(t, 200, h.writer.Code)
+}
+
+func TestJsonPost__Trailing_Data(t *testing.T) {
+	for _, body := range []string{
+		`{"id":9} garbage`,
+		`{"id":9}}`,
+		`{"id":9} {"id":10}`,
+	} {
+		h := newHtmlTest()
+
+		urlPath := urls.New[htmlParams]("/api/users/{id}")
+
+		var inputParams []htmlParams
+		JsonPost(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+			inputParams = append(inputParams, params)
+			return jsonResponse{}, nil
+		})
+
+		h.doJson(http.MethodPost, "/api/users/123", body)
+
+		assert.Equal(t, []htmlParams(nil), inputParams, body)
+		assert.Equal(t, 400, h.writer.Code, body)
+		assert.Equal(t, `{"error":"invalid trailing data after json body"}`+"\n", h.writer.Body.String(), body)
+	}
+
+	// trailing spaces are allowed
+	h := newHtmlTest()
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+	JsonPost(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		return jsonResponse{}, nil
+	})
+	h.doJson(http.MethodPost, "/api/users/123", "{\"id\":9} \n")
+	assert.Equal(t, 200, h.writer.Code)
+}
+
+func TestJsonPatch__Empty_Body(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+
+	var inputParams []htmlParams
+	JsonPatch(h.router, urlPath, func(ctx Context, params htmlParams) (*jsonResponse, error) {
+		inputParams = append(inputParams, params)
+		return nil, nil
+	})
+
+	h.doJson(http.MethodPatch, "/api/users/123", "")
+
+	// check input
+	assert.Equal(t, []htmlParams{
+		{ID: 123},
+	}, inputParams)
+
+	// check output
+	assert.Equal(t, 200, h.writer.Code)
+	assert.Equal(t, "null\n", h.writer.Body.String())
+}
+
+func TestJsonPut__Invalid_Body(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+
+	var inputParams []htmlParams
+	JsonPut(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		inputParams = append(inputParams, params)
+		return jsonResponse{}, nil
+	})
+
+	h.doJson(http.MethodPut, "/api/users/123", `{"age": "invalid"}`)
+
+	// check input
+	assert.Equal(t, []htmlParams(nil), inputParams)
+
+	// check output
+	assert.Equal(t, 400, h.writer.Code)
+	assert.Equal(t,
+		`{"error":"json: cannot unmarshal string into Go struct field htmlParams.age of type int64"}`+"\n",
+		h.writer.Body.String(),
+	)
+	assert.Equal(t, http.Header{
+		"Content-Type": []string{"application/json"},
+	}, h.writer.Header())
+}
+
+func TestJsonPost__Handler_Error__Hide_Cause(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+	JsonPost(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		return jsonResponse{}, errors.New("db password=secret failed")
+	})
+
+	h.doJson(http.MethodPost, "/api/users/123", "{}")
+
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t, `{"error":"Internal Server Error"}`+"\n", h.writer.Body.String())
+}
+
+func TestJsonDelete__Handler_Error__WithCustomErrorHandler(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+
+	JsonDelete(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		return jsonResponse{}, errors.New("handler error")
+	})
+
+	h.router.SetCustomJsonErrorHandler(func(ctx Context, err error) {
+		writer := ctx.GetWriter()
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte(err.Error()))
+	})
+
+	h.doJson(http.MethodDelete, "/api/users/123", "")
+
+	// check output
+	assert.Equal(t, 500, h.writer.Code)
+	assert.Equal(t, "handler error", h.writer.Body.String())
+}
+
+func TestJsonGet__Duplicated_Pattern__Panic(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+
+	JsonGet(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		return jsonResponse{}, nil
+	})
+
+	assert.PanicsWithValue(t, "GET /users/{id} is already defined", func() {
+		JsonGet(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+			return jsonResponse{}, nil
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/QuangTung97/weblib/urls"
+)
+
+const defaultMultipartMaxMemory = 32 << 20 // 32 MB, same as net/http
+
+type multipartConfig struct {
+	maxMemory   int64
+	maxBodySize int64
+}
+
+// WithMultipartLimits creates a new Router object with limits for multipart/form-data requests.
+// Files exceeding maxMemory are stored in temporary files, which are removed after the handler returns.
+// Requests with body larger than maxBodySize are rejected with status 413, zero means no limit.
+// The old Router is unchanged
+func (r *Router) WithMultipartLimits(maxMemory int64, maxBodySize int64) *Router {
+	newRouter := *r
+	newRouter.multipart = multipartConfig{
+		maxMemory:   maxMemory,
+		maxBodySize: maxBodySize,
+	}
+	return &newRouter
+}
+
+// -------------------------------------------------------------------------
+// Internal Implementation
+// -------------------------------------------------------------------------
+
+func isMultipartRequest(req *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return mediaType == "multipart/form-data"
+}
+
+func parseMultipartForm(req *http.Request, conf multipartConfig) error {
+	if conf.maxBodySize > 0 {
+		req.Body = http.MaxBytesReader(nil, req.Body, conf.maxBodySize)
+	}
+
+	maxMemory := conf.maxMemory
+	if maxMemory <= 0 {
+		maxMemory = defaultMultipartMaxMemory
+	}
+
+	err := req.ParseMultipartForm(maxMemory)
+	if err == nil {
+		return nil
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return NewHttpError(
+			http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit),
+		).WithCause(err)
+	}
+
+	return &HtmlError{
+		Reason:  ReasonBadFormParam,
+		Message: err.Error(),
+	}
+}
+
+func bindMultipartFiles[T any](req *http.Request, urlPath urls.Path[T], params *T) error {
+	err := urls.SetStructWithFiles(params, urlPath.GetNonPathParams(), func(name string) *multipart.FileHeader {
+		files := req.MultipartForm.File[name]
+		if len(files) == 0 {
+			return nil
+		}
+		return files[0]
+	})
+	if err != nil {
+		return &HtmlError{
+			Reason:  ReasonBadFormParam,
+			Message: err.Error(),
+		}
+	}
+	return nil
+}
+
+// removeMultipartFiles removes temporary files after the handler returned
+func removeMultipartFiles(req *http.Request) {
+	if req.MultipartForm == nil {
+		return
+	}
+	_ = req.MultipartForm.RemoveAll()
+}
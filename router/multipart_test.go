@@ -0,0 +1,154 @@
+package router
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+type uploadParams struct {
+	ID     int64     `json:"id"`
+	Title  string    `json:"title"`
+	Avatar urls.File `json:"avatar"`
+}
+
+func (h *htmlTest) doMultipart(postURL string, fields map[string]string, fileName string, fileContent string) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for key, value := range fields {
+		_ = mw.WriteField(key, value)
+	}
+	if len(fileName) > 0 {
+		fw, err := mw.CreateFormFile("avatar", fileName)
+		if err != nil {
+			panic(err)
+		}
+		_, _ = fw.Write([]byte(fileContent))
+	}
+	_ = mw.Close()
+
+	h.req = httptest.NewRequest(http.MethodPost, postURL, &body)
+	h.req.Header.Set("Content-Type", mw.FormDataContentType())
+	h.serve()
+}
+
+func TestHtmlPost__Multipart(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[uploadParams]("/users/{id}/avatar")
+
+	var inputParams []uploadParams
+	var fileContents []string
+	HtmlPost(h.router, urlPath, func(ctx Context, params uploadParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+
+		file, err := params.Avatar.Open()
+		if err != nil {
+			return hx.None(), err
+		}
+		defer func() { _ = file.Close() }()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return hx.None(), err
+		}
+		fileContents = append(fileContents, string(data))
+
+		return hx.Div(hx.Text("uploaded")), nil
+	})
+
+	h.doMultipart("/users/123/avatar", map[string]string{
+		"title": "my avatar",
+	}, "avatar.png", "png content")
+
+	// check output
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, "<div>uploaded</div>", h.writer.Body.String())
+
+	// check input
+	assert.Equal(t, 1, len(inputParams))
+	params := inputParams[0]
+	assert.Equal(t, int64(123), params.ID)
+	assert.Equal(t, "my avatar", params.Title)
+	assert.Equal(t, "avatar.png", params.Avatar.Filename())
+	assert.Equal(t, int64(11), params.Avatar.Size())
+	assert.Equal(t, "application/octet-stream", params.Avatar.ContentType())
+	assert.Equal(t, []string{"png content"}, fileContents)
+}
+
+func TestHtmlPost__Multipart__Without_File(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[uploadParams]("/users/{id}/avatar")
+
+	var inputParams []uploadParams
+	HtmlPost(h.router, urlPath, func(ctx Context, params uploadParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+		return hx.None(), nil
+	})
+
+	h.doMultipart("/users/123/avatar", map[string]string{
+		"title": "no avatar",
+	}, "", "")
+
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, []uploadParams{
+		{ID: 123, Title: "no avatar"},
+	}, inputParams)
+}
+
+func TestHtmlPost__Multipart__Temp_Files_Removed(t *testing.T) {
+	h := newHtmlTest()
+	h.router = h.router.WithMultipartLimits(1, 0)
+
+	urlPath := urls.New[uploadParams]("/users/{id}/avatar")
+
+	var tempFileNames []string
+	HtmlPost(h.router, urlPath, func(ctx Context, params uploadParams) (hx.Elem, error) {
+		file, err := params.Avatar.Open()
+		if err != nil {
+			return hx.None(), err
+		}
+		defer func() { _ = file.Close() }()
+
+		osFile, ok := file.(*os.File)
+		assert.Equal(t, true, ok)
+		tempFileNames = append(tempFileNames, osFile.Name())
+		return hx.None(), nil
+	})
+
+	h.doMultipart("/users/123/avatar", nil, "avatar.png", "large file content")
+
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, 1, len(tempFileNames))
+
+	_, err := os.Stat(tempFileNames[0])
+	assert.Equal(t, true, os.IsNotExist(err))
+}
+
+func TestHtmlPost__Multipart__Body_Too_Large(t *testing.T) {
+	h := newHtmlTest()
+	h.router = h.router.WithMultipartLimits(1024, 100)
+
+	urlPath := urls.New[uploadParams]("/users/{id}/avatar")
+
+	var inputParams []uploadParams
+	HtmlPost(h.router, urlPath, func(ctx Context, params uploadParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+		return hx.None(), nil
+	})
+
+	h.doMultipart("/users/123/avatar", nil, "avatar.png", string(make([]byte, 200)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, h.writer.Code)
+	assert.Equal(t, []uploadParams(nil), inputParams)
+}
@@ -0,0 +1,74 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recovery returns a middleware that converts panics of handlers into *HttpError with status 500.
+// So that panics are handled by the configured error handler.
+// It can be used in both WithMiddlewares and AddFinalizeHook
+func Recovery() Middleware {
+	return func(handler GenericHandler) GenericHandler {
+		return func(ctx Context, req any) (resp any, err error) {
+			defer func() {
+				panicValue := recover()
+				if panicValue == nil {
+					return
+				}
+
+				resp = nil
+				err = recoveredPanicError(panicValue)
+			}()
+
+			return handler(ctx, req)
+		}
+	}
+}
+
+// EnableRecovery recovers panics of the whole request handling of all endpoints,
+// including binding params and writing responses (e.g. lazy iterators of hx.Collect).
+// The panics are handled by the error handler of the endpoint as *HttpError with status 500,
+// or only logged when the response is already being sent (e.g. RenderModeDirect)
+func (r *Router) EnableRecovery() {
+	r.state.recovery = true
+}
+
+var RecoveryLogFunc = func(panicValue any, stack []byte) {
+	slog.Error("handler panic", "panic", panicValue, "stack", string(stack))
+}
+
+// recoverEndpoint must be called directly by defer.
+// The panic is only logged if the response headers are already written
+func recoverEndpoint(ctx Context, handleError func(ctx Context, err error)) {
+	panicValue := recover()
+	if panicValue == nil {
+		return
+	}
+
+	err := recoveredPanicError(panicValue)
+	if ctx.state.headerWritten {
+		return
+	}
+	handleError(ctx, err)
+}
+
+func recoveredPanicError(panicValue any) error {
+	// keep the behaviour of net/http for aborting request
+	if panicValue == http.ErrAbortHandler {
+		panic(panicValue)
+	}
+
+	RecoveryLogFunc(panicValue, debug.Stack())
+	return InternalError(panicToError(panicValue))
+}
+
+func panicToError(panicValue any) error {
+	if err, ok := panicValue.(error); ok {
+		return fmt.Errorf("panic: %w", err)
+	}
+	return errors.New(fmt.Sprint("panic: ", panicValue))
+}
@@ -0,0 +1,225 @@
+package router
+
+import (
+	"errors"
+	"iter"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func (h *htmlTest) stubRecoveryLog(t *testing.T) *[]string {
+	var stacks []string
+
+	oldFunc := RecoveryLogFunc
+	RecoveryLogFunc = func(panicValue any, stack []byte) {
+		h.addAction("recovery-log")
+		stacks = append(stacks, string(stack))
+	}
+	t.Cleanup(func() {
+		RecoveryLogFunc = oldFunc
+	})
+
+	return &stacks
+}
+
+func TestRecovery__Handler_Panic(t *testing.T) {
+	h := newHtmlTest()
+	stacks := h.stubRecoveryLog(t)
+
+	h.router = h.router.WithMiddlewares(Recovery())
+	h.addMiddlewares()
+
+	var handlerErr error
+	h.router.SetCustomHtmlErrorHandler(func(ctx Context, err error) {
+		handlerErr = err
+		h.router.DefaultHtmlErrorHandler(ctx, err)
+	})
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		h.addAction("handler")
+		panic("some panic")
+	})
+
+	h.req = newHxRequest(http.MethodGet, "/users/123")
+	h.serve()
+
+	// check output
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t,
+		`<div class="http-error" role="alert"><h1>500 Internal Server Error</h1><p>Internal Server Error</p></div>`,
+		h.writer.Body.String(),
+	)
+
+	// check error
+	assert.Equal(t, "Internal Server Error: panic: some panic", handlerErr.Error())
+
+	// check stack trace
+	assert.Equal(t, 1, len(*stacks))
+	assert.Equal(t, true, strings.Contains((*stacks)[0], "TestRecovery__Handler_Panic"))
+
+	// check actions
+	assert.Equal(t, []string{
+		"middleware01",
+		"middleware02",
+		"handler",
+		"middleware02_end",
+		"middleware01_end",
+		"recovery-log",
+	}, h.actions)
+}
+
+func TestRecovery__Finalize_Hook__Panic_With_Error(t *testing.T) {
+	h := newHtmlTest()
+	h.stubRecoveryLog(t)
+
+	h.router.AddFinalizeHook(Recovery())
+
+	panicErr := errors.New("panic error")
+
+	var handlerErr error
+	h.router.SetCustomHtmlErrorHandler(func(ctx Context, err error) {
+		handlerErr = err
+	})
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		panic(panicErr)
+	})
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, true, errors.Is(handlerErr, panicErr))
+
+	var httpErr *HttpError
+	assert.Equal(t, true, errors.As(handlerErr, &httpErr))
+	assert.Equal(t, http.StatusInternalServerError, httpErr.Status)
+}
+
+func TestRecovery__No_Panic(t *testing.T) {
+	h := newHtmlTest()
+	h.stubRecoveryLog(t)
+
+	h.router = h.router.WithMiddlewares(Recovery())
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return hx.Div(hx.Text("Hello")), nil
+	})
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, "<div>Hello</div>", h.writer.Body.String())
+	assert.Equal(t, []string(nil), h.actions)
+}
+
+func TestRouter_EnableRecovery__Render_Panic(t *testing.T) {
+	h := newHtmlTest()
+	stacks := h.stubRecoveryLog(t)
+
+	h.router.EnableRecovery()
+	h.router = h.router.WithRenderMode(RenderModeBuffered)
+
+	var handlerErr error
+	h.router.SetCustomHtmlErrorHandler(func(ctx Context, err error) {
+		handlerErr = err
+		h.router.DefaultHtmlErrorHandler(ctx, err)
+	})
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		h.addAction("handler")
+		var seq iter.Seq[hx.Elem] = func(yield func(hx.Elem) bool) {
+			panic("render panic")
+		}
+		return hx.Div(hx.Collect(seq)), nil
+	})
+
+	h.req = newHxRequest(http.MethodGet, "/users/123")
+	h.serve()
+
+	// check output
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t,
+		`<div class="http-error" role="alert"><h1>500 Internal Server Error</h1><p>Internal Server Error</p></div>`,
+		h.writer.Body.String(),
+	)
+
+	// check error
+	assert.Equal(t, "Internal Server Error: panic: render panic", handlerErr.Error())
+
+	// check stack trace
+	assert.Equal(t, 1, len(*stacks))
+	assert.Equal(t, true, strings.Contains((*stacks)[0], "TestRouter_EnableRecovery__Render_Panic"))
+
+	// check actions
+	assert.Equal(t, []string{
+		"handler",
+		"recovery-log",
+	}, h.actions)
+}
+
+func TestRouter_EnableRecovery__Render_Panic__Direct_Mode(t *testing.T) {
+	h := newHtmlTest()
+	h.stubRecoveryLog(t)
+
+	h.router.EnableRecovery()
+
+	var handlerErr error
+	h.router.SetCustomHtmlErrorHandler(func(ctx Context, err error) {
+		handlerErr = err
+	})
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		var seq iter.Seq[hx.Elem] = func(yield func(hx.Elem) bool) {
+			panic("render panic")
+		}
+		return hx.Div(hx.Collect(seq)), nil
+	})
+
+	h.doGet("/users/123")
+
+	// response is already sent, the panic is only logged
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, "<div>", h.writer.Body.String())
+	assert.Equal(t, nil, handlerErr)
+	assert.Equal(t, []string{"recovery-log"}, h.actions)
+}
+
+func TestRouter_EnableRecovery__Json_Bind_Params_Panic(t *testing.T) {
+	h := newHtmlTest()
+	h.stubRecoveryLog(t)
+
+	h.router.EnableRecovery()
+
+	urlPath := urls.New[htmlParams]("/api/users/{id}")
+	JsonPost(h.router, urlPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		return jsonResponse{}, nil
+	})
+
+	h.req = newHxRequest(http.MethodPost, "/api/users/123")
+	h.req.Body = panicReader{}
+	h.serve()
+
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t, `{"error":"Internal Server Error"}`+"\n", h.writer.Body.String())
+	assert.Equal(t, []string{"recovery-log"}, h.actions)
+}
+
+type panicReader struct{}
+
+func (panicReader) Read([]byte) (int, error) {
+	panic("read panic")
+}
+
+func (panicReader) Close() error {
+	return nil
+}
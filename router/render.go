@@ -0,0 +1,152 @@
+package router
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/QuangTung97/weblib/hx"
+)
+
+type RenderMode int
+
+const (
+	// RenderModeDirect renders hx.Elem directly into the response writer.
+	// Render errors can only be logged because the response might already be sent
+	RenderModeDirect RenderMode = iota
+
+	// RenderModeBuffered renders into a pooled buffer, then sends it with Content-Length.
+	// Render errors are routed to the html error handler
+	RenderModeBuffered
+
+	// RenderModeStreaming renders directly into the response writer,
+	// and flushes periodically after every flush size bytes
+	RenderModeStreaming
+)
+
+const defaultStreamingFlushSize = 32 * 1024
+
+// WithRenderMode creates a new Router object with the render mode for html handlers.
+// The old Router is unchanged
+func (r *Router) WithRenderMode(mode RenderMode) *Router {
+	newRouter := *r
+	newRouter.renderMode = mode
+	return &newRouter
+}
+
+// WithStreamingFlushSize creates a new Router object with the number of bytes between flushes,
+// only used for RenderModeStreaming. The old Router is unchanged
+func (r *Router) WithStreamingFlushSize(size int) *Router {
+	newRouter := *r
+	newRouter.streamingFlushSize = size
+	return &newRouter
+}
+
+// -------------------------------------------------------------------------
+// Internal Implementation
+// -------------------------------------------------------------------------
+
+func (r *Router) newHtmlResponseWriter() func(ctx Context, resp any) error {
+	mode := r.renderMode
+	flushSize := r.streamingFlushSize
+	if flushSize <= 0 {
+		flushSize = defaultStreamingFlushSize
+	}
+
+	return func(ctx Context, resp any) error {
+		outputElem, ok := resp.(hx.Elem)
+		if !ok {
+			err := fmt.Errorf("failed to convert response to hx.Elem")
+			return &HtmlError{
+				Reason:  ReasonBadResponseType,
+				Message: err.Error(),
+			}
+		}
+
+		ctx.state.responded = true
+		writer := ctx.GetWriter()
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		switch mode {
+		case RenderModeBuffered:
+			return renderBuffered(writer, outputElem)
+
+		case RenderModeStreaming:
+			w := &flushWriter{
+				writer:    writer,
+				flushSize: flushSize,
+			}
+			err := outputElem.Render(w)
+			w.flush()
+			logRenderError(err)
+			return nil
+
+		default:
+			logRenderError(outputElem.Render(writer))
+			return nil
+		}
+	}
+}
+
+const maxPooledBufferSize = 1 << 20
+
+var renderBufferPool = sync.Pool{
+	New: func() any {
+		return &bytes.Buffer{}
+	},
+}
+
+func renderBuffered(writer http.ResponseWriter, elem hx.Elem) error {
+	buf := renderBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			renderBufferPool.Put(buf)
+		}
+	}()
+
+	if err := elem.Render(buf); err != nil {
+		return InternalError(fmt.Errorf("failed to render html: %w", err))
+	}
+
+	writer.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	_, _ = writer.Write(buf.Bytes())
+	return nil
+}
+
+func logRenderError(err error) {
+	if err == nil {
+		return
+	}
+	slog.Error("failed to render html response", "error", err.Error())
+}
+
+type flushWriter struct {
+	writer    io.Writer
+	flushSize int
+	unflushed int
+}
+
+func (w *flushWriter) Write(data []byte) (int, error) {
+	n, err := w.writer.Write(data)
+	w.unflushed += n
+	if err != nil {
+		return n, err
+	}
+
+	if w.unflushed >= w.flushSize {
+		w.flush()
+	}
+	return n, nil
+}
+
+func (w *flushWriter) flush() {
+	w.unflushed = 0
+	if flusher, ok := w.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,142 @@
+package router
+
+import (
+	"errors"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func newListElem(size int, failAt int) hx.Elem {
+	var seq iter.Seq2[hx.Elem, error] = func(yield func(hx.Elem, error) bool) {
+		for i := range size {
+			if i == failAt {
+				yield(hx.None(), errors.New("iterator error"))
+				return
+			}
+			if !yield(hx.Li(hx.Text(strconv.Itoa(i))), nil) {
+				return
+			}
+		}
+	}
+	return hx.Ul(hx.CollectWithError(seq))
+}
+
+func TestRender__Direct__With_Error(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return newListElem(3, 2), nil
+	})
+
+	h.doGet("/users/123")
+
+	// response is truncated
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, "<ul><li>0</li><li>1</li>", h.writer.Body.String())
+}
+
+func TestRender__Buffered(t *testing.T) {
+	h := newHtmlTest()
+	h.router = h.router.WithRenderMode(RenderModeBuffered)
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return newListElem(2, -1), nil
+	})
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, "<ul><li>0</li><li>1</li></ul>", h.writer.Body.String())
+	assert.Equal(t, http.Header{
+		"Content-Type":   {"text/html; charset=utf-8"},
+		"Content-Length": {"29"},
+	}, h.writer.Header())
+	assert.Equal(t, false, h.writer.Flushed)
+}
+
+func TestRender__Buffered__With_Error(t *testing.T) {
+	h := newHtmlTest()
+	h.router = h.router.WithRenderMode(RenderModeBuffered)
+
+	var handlerErr error
+	h.router.SetCustomHtmlErrorHandler(func(ctx Context, err error) {
+		handlerErr = err
+		h.router.DefaultHtmlErrorHandler(ctx, err)
+	})
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return newListElem(3, 2), nil
+	})
+
+	h.req = newHxRequest(http.MethodGet, "/users/123")
+	h.serve()
+
+	assert.Equal(t, http.StatusInternalServerError, h.writer.Code)
+	assert.Equal(t,
+		`<div class="http-error" role="alert"><h1>500 Internal Server Error</h1><p>Internal Server Error</p></div>`,
+		h.writer.Body.String(),
+	)
+	assert.Equal(t, "Internal Server Error: failed to render html: iterator error", handlerErr.Error())
+}
+
+func TestRender__Streaming(t *testing.T) {
+	h := newHtmlTest()
+	h.router = h.router.WithRenderMode(RenderModeStreaming).WithStreamingFlushSize(64)
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	HtmlGet(h.router, urlPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return newListElem(100, -1), nil
+	})
+
+	h.doGet("/users/123")
+
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, true, strings.HasPrefix(h.writer.Body.String(), "<ul><li>0</li><li>1</li>"))
+	assert.Equal(t, true, strings.HasSuffix(h.writer.Body.String(), "<li>99</li></ul>"))
+	assert.Equal(t, true, h.writer.Flushed)
+	assert.Equal(t, "", h.writer.Header().Get("Content-Length"))
+}
+
+func TestFlushWriter(t *testing.T) {
+	r := &flushRecorder{}
+	w := &flushWriter{
+		writer:    r,
+		flushSize: 10,
+	}
+
+	_, _ = w.Write([]byte("12345"))
+	assert.Equal(t, 0, r.flushCount)
+
+	_, _ = w.Write([]byte("123456"))
+	assert.Equal(t, 1, r.flushCount)
+
+	_, _ = w.Write([]byte("1234"))
+	assert.Equal(t, 1, r.flushCount)
+
+	w.flush()
+	assert.Equal(t, 2, r.flushCount)
+	assert.Equal(t, 0, w.unflushed)
+}
+
+type flushRecorder struct {
+	flushCount int
+}
+
+func (r *flushRecorder) Write(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (r *flushRecorder) Flush() {
+	r.flushCount++
+}
@@ -9,6 +9,11 @@ type Router struct {
 	middlewares     []Middleware
 	urlPrefix       string
 	paramValidators []func(params any)
+
+	renderMode         RenderMode
+	streamingFlushSize int
+
+	multipart multipartConfig
 }
 
 func NewRouter() *Router {
@@ -21,11 +26,12 @@ func NewRouterWithChi(chiRouter chi.Router) *Router {
 	r := &Router{
 		state: &routerState{
 			chi:        chiRouter,
-			registered: map[endpointKey]struct{}{},
+			registered: map[endpointKey]int{},
 		},
 	}
 
 	r.state.handleHtmlError = r.DefaultHtmlErrorHandler
+	r.state.handleJsonError = r.DefaultJsonErrorHandler
 
 	return r
 }
@@ -58,9 +64,13 @@ type endpointKey struct {
 }
 type routerState struct {
 	chi        chi.Router
-	registered map[endpointKey]struct{}
+	registered map[endpointKey]int // index to routes
+	routes     []RouteInfo
 
 	finalHooks []Middleware
 
 	handleHtmlError func(ctx Context, err error)
+	handleJsonError func(ctx Context, err error)
+
+	recovery bool
 }
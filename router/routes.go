@@ -0,0 +1,80 @@
+package router
+
+import (
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+)
+
+// RouteInfo describes a registered endpoint
+type RouteInfo struct {
+	Method  string
+	Pattern string
+
+	ParamsType    reflect.Type
+	PathParams    []string
+	NonPathParams []string
+
+	// Middlewares contains function names of middlewares and finalize hooks, in the order of execution
+	Middlewares []string
+}
+
+func (i RouteInfo) String() string {
+	return i.Method + " " + i.Pattern
+}
+
+// GetRoutes returns all registered routes, shared across *Router objects, in the order of registration
+func (r *Router) GetRoutes() []RouteInfo {
+	return slices.Clone(r.state.routes)
+}
+
+// FindRoute finds the registered route by method and url pattern
+func (r *Router) FindRoute(method string, pattern string) (RouteInfo, bool) {
+	key := endpointKey{
+		method:  method,
+		pattern: pattern,
+	}
+	index, ok := r.state.registered[key]
+	if !ok {
+		return RouteInfo{}, false
+	}
+	return r.state.routes[index], true
+}
+
+// FindRoutesByPattern returns the registered routes of all methods of the url pattern
+func (r *Router) FindRoutesByPattern(pattern string) []RouteInfo {
+	var result []RouteInfo
+	for _, route := range r.state.routes {
+		if route.Pattern == pattern {
+			result = append(result, route)
+		}
+	}
+	return result
+}
+
+// -------------------------------------------------------------------------
+// Internal Implementation
+// -------------------------------------------------------------------------
+
+func (r *Router) getMiddlewareNames() []string {
+	var result []string
+	for _, mw := range r.middlewares {
+		result = append(result, getFuncName(mw))
+	}
+	for _, hook := range r.state.finalHooks {
+		result = append(result, getFuncName(hook))
+	}
+	return result
+}
+
+var closureSuffixRegex = regexp.MustCompile(`(\.func\d+)+$|-fm$`)
+
+func getFuncName(fn Middleware) string {
+	pc := reflect.ValueOf(fn).Pointer()
+	runtimeFunc := runtime.FuncForPC(pc)
+	if runtimeFunc == nil {
+		return "unknown"
+	}
+	return closureSuffixRegex.ReplaceAllString(runtimeFunc.Name(), "")
+}
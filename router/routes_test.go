@@ -0,0 +1,99 @@
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func testMiddleware(handler GenericHandler) GenericHandler {
+	return handler
+}
+
+func TestRouter_GetRoutes(t *testing.T) {
+	h := newHtmlTest()
+
+	h.router.AddFinalizeHook(Recovery())
+
+	userPath := urls.New[htmlParams]("/users/{id}")
+	homePath := urls.New[testParams01]("/home")
+
+	HtmlGet(h.router, userPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return hx.None(), nil
+	})
+
+	apiRouter := h.router.WithMiddlewares(testMiddleware)
+	JsonPost(apiRouter, userPath, func(ctx Context, params htmlParams) (jsonResponse, error) {
+		return jsonResponse{}, nil
+	})
+	HtmlGet(apiRouter, homePath, func(ctx Context, params testParams01) (hx.Elem, error) {
+		return hx.None(), nil
+	})
+
+	routes := h.router.GetRoutes()
+	assert.Equal(t, []RouteInfo{
+		{
+			Method:        http.MethodGet,
+			Pattern:       "/users/{id}",
+			ParamsType:    reflect.TypeFor[htmlParams](),
+			PathParams:    []string{"id"},
+			NonPathParams: []string{"search", "age"},
+			Middlewares: []string{
+				"github.com/QuangTung97/weblib/router.Recovery",
+			},
+		},
+		{
+			Method:        http.MethodPost,
+			Pattern:       "/users/{id}",
+			ParamsType:    reflect.TypeFor[htmlParams](),
+			PathParams:    []string{"id"},
+			NonPathParams: []string{"search", "age"},
+			Middlewares: []string{
+				"github.com/QuangTung97/weblib/router.testMiddleware",
+				"github.com/QuangTung97/weblib/router.Recovery",
+			},
+		},
+		{
+			Method:        http.MethodGet,
+			Pattern:       "/home",
+			ParamsType:    reflect.TypeFor[testParams01](),
+			NonPathParams: []string{"id"},
+			Middlewares: []string{
+				"github.com/QuangTung97/weblib/router.testMiddleware",
+				"github.com/QuangTung97/weblib/router.Recovery",
+			},
+		},
+	}, routes)
+	assert.Equal(t, "POST /users/{id}", routes[1].String())
+
+	// find route
+	route, ok := apiRouter.FindRoute(http.MethodGet, "/home")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, routes[2], route)
+
+	_, ok = apiRouter.FindRoute(http.MethodPost, "/home")
+	assert.Equal(t, false, ok)
+
+	// find by pattern
+	assert.Equal(t, routes[:2], h.router.FindRoutesByPattern(userPath.GetPattern()))
+	assert.Equal(t, []RouteInfo(nil), h.router.FindRoutesByPattern("/not-found"))
+}
+
+func TestRouter_GetRoutes__Prefix_Not_Match__Not_Recorded(t *testing.T) {
+	h := newHtmlTest()
+
+	h.router = h.router.WithGroup("/api")
+	productPath := urls.New[htmlParams]("/products/{id}")
+
+	assert.Panics(t, func() {
+		HtmlGet(h.router, productPath, func(ctx Context, params htmlParams) (hx.Elem, error) {
+			return hx.None(), nil
+		})
+	})
+	assert.Equal(t, []RouteInfo(nil), h.router.GetRoutes())
+}
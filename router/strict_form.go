@@ -0,0 +1,50 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+// StrictForm renders hx.StrictFormFor with the method derived from the registered routes of the url path.
+// The only non GET method is used if existed, otherwise GET is used.
+// If no route is registered or there are multiple non GET methods,
+// the violation is logged and rendered using hx.StrictFormViolation instead of the form
+func StrictForm[T any](router *Router, urlPath urls.Path[T], params T, children ...hx.Elem) hx.Elem {
+	pattern := urlPath.GetPattern()
+	method, err := router.getFormMethod(pattern)
+	if err != nil {
+		return hx.StrictFormViolation(pattern, "StrictForm violation: "+err.Error())
+	}
+	return hx.StrictFormFor(method, urlPath, params, children...)
+}
+
+// -------------------------------------------------------------------------
+// Internal Implementation
+// -------------------------------------------------------------------------
+
+func (r *Router) getFormMethod(pattern string) (string, error) {
+	routes := r.FindRoutesByPattern(pattern)
+	if len(routes) == 0 {
+		return "", fmt.Errorf("no route is registered for pattern '%s'", pattern)
+	}
+
+	var nonGetMethods []string
+	for _, route := range routes {
+		if route.Method == http.MethodGet {
+			continue
+		}
+		nonGetMethods = append(nonGetMethods, route.Method)
+	}
+
+	switch len(nonGetMethods) {
+	case 0:
+		return http.MethodGet, nil
+	case 1:
+		return nonGetMethods[0], nil
+	default:
+		return "", fmt.Errorf("multiple methods %v are registered for pattern '%s'", nonGetMethods, pattern)
+	}
+}
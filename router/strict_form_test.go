@@ -0,0 +1,94 @@
+package router
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+func renderElem(elem hx.Elem) string {
+	var buf bytes.Buffer
+	if err := elem.Render(&buf); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
+func TestStrictForm(t *testing.T) {
+	emptyHandler := func(ctx Context, params htmlParams) (hx.Elem, error) {
+		return hx.None(), nil
+	}
+
+	t.Run("only get", func(t *testing.T) {
+		h := newHtmlTest()
+		urlPath := urls.New[htmlParams]("/users/{id}")
+		HtmlGet(h.router, urlPath, emptyHandler)
+
+		elem := StrictForm(h.router, urlPath, htmlParams{ID: 11, Search: "abc"})
+		assert.Equal(t, `<form method="get" action="/users/11"></form>`, renderElem(elem))
+	})
+
+	t.Run("prefer non get method", func(t *testing.T) {
+		h := newHtmlTest()
+		urlPath := urls.New[htmlParams]("/users/{id}")
+		HtmlGet(h.router, urlPath, emptyHandler)
+		HtmlPatch(h.router, urlPath, emptyHandler)
+
+		elem := StrictForm(h.router, urlPath, htmlParams{ID: 11},
+			hx.Input(hx.Name("search")),
+		)
+		assert.Equal(t, `<form hx-patch="/users/11"><input name="search"></form>`, renderElem(elem))
+	})
+
+	t.Run("post", func(t *testing.T) {
+		h := newHtmlTest()
+		urlPath := urls.New[htmlParams]("/users/{id}")
+		HtmlPost(h.router, urlPath, emptyHandler)
+
+		elem := StrictForm(h.router, urlPath, htmlParams{ID: 11})
+		assert.Equal(t, `<form method="post" action="/users/11"></form>`, renderElem(elem))
+	})
+
+	var violations []string
+	oldLogFunc := hx.StrictFormErrorLogFunc
+	hx.StrictFormErrorLogFunc = func(inputName string) {
+		violations = append(violations, inputName)
+	}
+	t.Cleanup(func() {
+		hx.StrictFormErrorLogFunc = oldLogFunc
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		violations = nil
+		h := newHtmlTest()
+		urlPath := urls.New[htmlParams]("/users/{id}")
+
+		elem := StrictForm(h.router, urlPath, htmlParams{ID: 11})
+		assert.Equal(t,
+			`<div class="text-2xl text-red-700">`+
+				`StrictForm violation: no route is registered for pattern &#39;/users/{id}&#39;</div>`,
+			renderElem(elem),
+		)
+		assert.Equal(t, []string{"/users/{id}"}, violations)
+	})
+
+	t.Run("multiple non get methods", func(t *testing.T) {
+		violations = nil
+		h := newHtmlTest()
+		urlPath := urls.New[htmlParams]("/users/{id}")
+		HtmlPost(h.router, urlPath, emptyHandler)
+		HtmlDelete(h.router, urlPath, emptyHandler)
+
+		elem := StrictForm(h.router, urlPath, htmlParams{ID: 11})
+		assert.Equal(t,
+			`<div class="text-2xl text-red-700">`+
+				`StrictForm violation: multiple methods [POST DELETE] are registered for pattern &#39;/users/{id}&#39;</div>`,
+			renderElem(elem),
+		)
+		assert.Equal(t, []string{"/users/{id}"}, violations)
+	})
+}
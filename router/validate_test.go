@@ -0,0 +1,113 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+type signupParams struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username" validate:"required,min=3"`
+	Role     string `json:"role" validate:"oneof=admin member"`
+}
+
+func TestHtmlPost__Validation_Error(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[signupParams]("/signup/{id}")
+
+	var inputParams []signupParams
+	HtmlPost(h.router, urlPath, func(ctx Context, params signupParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+		return hx.None(), nil
+	})
+
+	h.doMethod(http.MethodPost, "/signup/11", url.Values{
+		"username": {"ab"},
+		"role":     {"guest"},
+	})
+
+	// check input, handler is not called
+	assert.Equal(t, []signupParams(nil), inputParams)
+
+	// check output
+	assert.Equal(t, http.StatusUnprocessableEntity, h.writer.Code)
+	assert.Equal(t,
+		`<div class="validation-error" role="alert"><ul>`+
+			`<li data-field="username">username: must be at least 3 characters</li>`+
+			`<li data-field="role">role: must be one of: admin, member</li>`+
+			`</ul></div>`,
+		h.writer.Body.String(),
+	)
+}
+
+func TestHtmlPost__Validation_Success(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[signupParams]("/signup/{id}")
+
+	var inputParams []signupParams
+	HtmlPost(h.router, urlPath, func(ctx Context, params signupParams) (hx.Elem, error) {
+		inputParams = append(inputParams, params)
+		return hx.None(), nil
+	})
+
+	h.doMethod(http.MethodPost, "/signup/11", url.Values{
+		"username": {"user01"},
+	})
+
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, []signupParams{
+		{ID: 11, Username: "user01"},
+	}, inputParams)
+}
+
+func TestHtmlPost__Validation_Error__Custom_Error_Handler(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[signupParams]("/signup/{id}")
+	HtmlPost(h.router, urlPath, func(ctx Context, params signupParams) (hx.Elem, error) {
+		return hx.None(), nil
+	})
+
+	h.router.SetCustomHtmlErrorHandler(func(ctx Context, err error) {
+		var validationErr *urls.ValidationError
+		if !errors.As(err, &validationErr) {
+			h.router.DefaultHtmlErrorHandler(ctx, err)
+			return
+		}
+
+		msg, _ := validationErr.Get("username")
+		_ = hx.Div(hx.Text(msg)).Render(ctx.GetWriter())
+	})
+
+	h.doMethod(http.MethodPost, "/signup/11", url.Values{})
+
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, `<div>is required</div>`, h.writer.Body.String())
+}
+
+func TestJsonPost__Validation_Error(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[signupParams]("/api/signup/{id}")
+	JsonPost(h.router, urlPath, func(ctx Context, params signupParams) (jsonResponse, error) {
+		return jsonResponse{}, nil
+	})
+
+	h.doJson(http.MethodPost, "/api/signup/11", `{"username":"ab"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, h.writer.Code)
+	assert.Equal(t,
+		`{"error":"validation failed: username: must be at least 3 characters",`+
+			`"fields":{"username":"must be at least 3 characters"}}`+"\n",
+		h.writer.Body.String(),
+	)
+}
@@ -0,0 +1,171 @@
+package router
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+// ViewGet set up Get handler that serves both html and json on the same url path.
+// The handler returns a view model, which is json encoded or rendered to html by the render function,
+// based on the Accept header of the request
+func ViewGet[T any, V any](
+	router *Router,
+	urlPath urls.Path[T],
+	handler func(ctx Context, params T) (V, error),
+	render func(view V) hx.Elem,
+) {
+	viewMethod(router, http.MethodGet, urlPath, handler, render)
+}
+
+// ViewPost set up Post handler that serves both html and json, see ViewGet
+func ViewPost[T any, V any](
+	router *Router,
+	urlPath urls.Path[T],
+	handler func(ctx Context, params T) (V, error),
+	render func(view V) hx.Elem,
+) {
+	viewMethod(router, http.MethodPost, urlPath, handler, render)
+}
+
+// ViewPut set up Put handler that serves both html and json, see ViewGet
+func ViewPut[T any, V any](
+	router *Router,
+	urlPath urls.Path[T],
+	handler func(ctx Context, params T) (V, error),
+	render func(view V) hx.Elem,
+) {
+	viewMethod(router, http.MethodPut, urlPath, handler, render)
+}
+
+// ViewPatch set up Patch handler that serves both html and json, see ViewGet
+func ViewPatch[T any, V any](
+	router *Router,
+	urlPath urls.Path[T],
+	handler func(ctx Context, params T) (V, error),
+	render func(view V) hx.Elem,
+) {
+	viewMethod(router, http.MethodPatch, urlPath, handler, render)
+}
+
+// ViewDelete set up Delete handler that serves both html and json, see ViewGet
+func ViewDelete[T any, V any](
+	router *Router,
+	urlPath urls.Path[T],
+	handler func(ctx Context, params T) (V, error),
+	render func(view V) hx.Elem,
+) {
+	viewMethod(router, http.MethodDelete, urlPath, handler, render)
+}
+
+// AcceptsJson returns true if the Accept header prefers application/json over text/html
+func (c Context) AcceptsJson() bool {
+	return prefersJson(c.Request.Header.Get("Accept"))
+}
+
+func viewMethod[T any, V any](
+	router *Router,
+	method string,
+	urlPath urls.Path[T],
+	handler func(ctx Context, params T) (V, error),
+	render func(view V) hx.Elem,
+) {
+	bindForm := bindFormParams(urlPath, router.multipart)
+	bindJson := bindJsonParams(urlPath)
+	writeHtml := router.newHtmlResponseWriter()
+
+	registerEndpoint(router, endpointConfig[T]{
+		method:  method,
+		urlPath: urlPath,
+
+		bindParams: func(req *http.Request, params *T) error {
+			mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			if mediaType == "application/json" {
+				return bindJson(req, params)
+			}
+			return bindForm(req, params)
+		},
+		handler: func(ctx Context, req any) (any, error) {
+			resp, err := handler(ctx, req.(T))
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		},
+		writeResponse: func(ctx Context, resp any) error {
+			ctx.writer.Header().Add("Vary", "Accept")
+			if ctx.AcceptsJson() {
+				return writeJsonResponse(ctx, resp)
+			}
+			return writeHtml(ctx, render(resp.(V)))
+		},
+		handleError: func(ctx Context, err error) {
+			ctx.writer.Header().Add("Vary", "Accept")
+			if ctx.AcceptsJson() {
+				router.state.handleJsonError(ctx, err)
+				return
+			}
+			router.state.handleHtmlError(ctx, err)
+		},
+	})
+}
+
+// prefersJson compares the quality values of the most specific media ranges
+// matching application/json and text/html
+func prefersJson(accept string) bool {
+	if len(accept) == 0 {
+		return false
+	}
+
+	jsonQuality := acceptQuality{}
+	htmlQuality := acceptQuality{}
+
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if qStr, ok := params["q"]; ok {
+			q, err := strconv.ParseFloat(qStr, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+
+		jsonQuality.update(mediaType, "application/json", quality)
+		htmlQuality.update(mediaType, "text/html", quality)
+	}
+
+	return jsonQuality.quality > htmlQuality.quality
+}
+
+type acceptQuality struct {
+	specificity int
+	quality     float64
+}
+
+func (q *acceptQuality) update(mediaRange string, mediaType string, quality float64) {
+	specificity := 0
+	switch {
+	case mediaRange == mediaType:
+		specificity = 3
+	case mediaRange == strings.Split(mediaType, "/")[0]+"/*":
+		specificity = 2
+	case mediaRange == "*/*":
+		specificity = 1
+	default:
+		return
+	}
+
+	if specificity > q.specificity {
+		q.specificity = specificity
+		q.quality = quality
+	}
+}
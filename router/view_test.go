@@ -0,0 +1,104 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/hx"
+	"github.com/QuangTung97/weblib/urls"
+)
+
+type userView struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func renderUserView(view userView) hx.Elem {
+	return hx.Div(hx.Text(view.Name))
+}
+
+func TestViewGet__Html_And_Json(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	ViewGet(h.router, urlPath, func(ctx Context, params htmlParams) (userView, error) {
+		return userView{ID: params.ID, Name: "user " + params.Search}, nil
+	}, renderUserView)
+
+	// html by default
+	h.doGet("/users/123?search=01")
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, "<div>user 01</div>", h.writer.Body.String())
+	assert.Equal(t, http.Header{
+		"Content-Type": {"text/html; charset=utf-8"},
+		"Vary":         {"Accept"},
+	}, h.writer.Header())
+
+	// json
+	h.req = httptest.NewRequest(http.MethodGet, "/users/123?search=02", nil)
+	h.req.Header.Set("Accept", "application/json")
+	h.serve()
+	assert.Equal(t, http.StatusOK, h.writer.Code)
+	assert.Equal(t, `{"id":123,"name":"user 02"}`+"\n", h.writer.Body.String())
+	assert.Equal(t, http.Header{
+		"Content-Type": {"application/json"},
+		"Vary":         {"Accept"},
+	}, h.writer.Header())
+}
+
+func TestViewPost__Json_Body(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	ViewPost(h.router, urlPath, func(ctx Context, params htmlParams) (userView, error) {
+		return userView{ID: params.ID, Name: params.Search}, nil
+	}, renderUserView)
+
+	h.req = httptest.NewRequest(http.MethodPost, "/users/123", strings.NewReader(`{"search":"json01"}`))
+	h.req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	h.req.Header.Set("Accept", "application/json")
+	h.serve()
+
+	assert.Equal(t, `{"id":123,"name":"json01"}`+"\n", h.writer.Body.String())
+}
+
+func TestViewGet__Error(t *testing.T) {
+	h := newHtmlTest()
+
+	urlPath := urls.New[htmlParams]("/users/{id}")
+	ViewGet(h.router, urlPath, func(ctx Context, params htmlParams) (userView, error) {
+		return userView{}, NotFound("user not found")
+	}, renderUserView)
+
+	// json
+	h.req = httptest.NewRequest(http.MethodGet, "/users/123", nil)
+	h.req.Header.Set("Accept", "application/json")
+	h.serve()
+	assert.Equal(t, http.StatusNotFound, h.writer.Code)
+	assert.Equal(t, `{"error":"user not found"}`+"\n", h.writer.Body.String())
+
+	// html
+	h.req = newHxRequest(http.MethodGet, "/users/123")
+	h.serve()
+	assert.Equal(t, http.StatusNotFound, h.writer.Code)
+	assert.Equal(t,
+		`<div class="http-error" role="alert"><h1>404 Not Found</h1><p>user not found</p></div>`,
+		h.writer.Body.String(),
+	)
+}
+
+func TestPrefersJson(t *testing.T) {
+	assert.Equal(t, false, prefersJson(""))
+	assert.Equal(t, false, prefersJson("*/*"))
+	assert.Equal(t, true, prefersJson("application/json"))
+	assert.Equal(t, false, prefersJson("text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"))
+	assert.Equal(t, true, prefersJson("text/html;q=0.5, application/json"))
+	assert.Equal(t, false, prefersJson("text/html, application/json"))
+	assert.Equal(t, true, prefersJson("application/*, text/html;q=0.9"))
+	assert.Equal(t, true, prefersJson("application/json, */*;q=0.1"))
+	assert.Equal(t, false, prefersJson("application/json;q=invalid"))
+}
@@ -0,0 +1,39 @@
+package urls
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldName returns the param name of the field selected by the selector, e.g.
+//
+//	name := urls.FieldName(func(p *UserParams) *string { return &p.Name })
+//
+// It panics if the selected field is not a param of the struct
+func FieldName[T any, F any](selector func(p *T) *F) string {
+	var obj T
+	objValue := reflect.ValueOf(&obj).Elem()
+
+	fieldPtr := selector(&obj)
+	fieldType := reflect.TypeFor[F]()
+
+	for jsonTag := range getAllJsonTagsOfValue(objValue) {
+		if jsonTag.err != nil {
+			panic(jsonTag.err.Error())
+		}
+
+		fieldVal := jsonTag.fieldValue
+		// address of a nested struct is the same as its first field, so types are also compared
+		if fieldVal.Type() != fieldType {
+			continue
+		}
+		if fieldVal.Addr().UnsafePointer() == reflect.ValueOf(fieldPtr).UnsafePointer() {
+			return jsonTag.name
+		}
+	}
+
+	panic(fmt.Sprintf(
+		"selected field with type '%s' is not a param of struct '%s'",
+		fieldType.String(), objValue.Type().Name(),
+	))
+}
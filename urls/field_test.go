@@ -0,0 +1,40 @@
+package urls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldName(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		assert.Equal(t, "id", FieldName(func(p *testParams) *int { return &p.ID }))
+		assert.Equal(t, "val", FieldName(func(p *testParams) *string { return &p.Val }))
+	})
+
+	t.Run("with url tag", func(t *testing.T) {
+		assert.Equal(t, "q", FieldName(func(p *tagParams) *string { return &p.Search }))
+	})
+
+	t.Run("embedded and nested", func(t *testing.T) {
+		assert.Equal(t, "page_size", FieldName(func(p *nestedParams) *int { return &p.PageSize }))
+		assert.Equal(t, "filter.status", FieldName(func(p *nestedParams) *string { return &p.Filter.Status }))
+		assert.Equal(t, "filter.range.from", FieldName(func(p *nestedParams) *int { return &p.Filter.Range.From }))
+	})
+
+	t.Run("not a param", func(t *testing.T) {
+		assert.PanicsWithValue(t,
+			"selected field with type 'string' is not a param of struct 'tagParams'",
+			func() {
+				FieldName(func(p *tagParams) *string { return &p.Internal })
+			},
+		)
+
+		assert.PanicsWithValue(t,
+			"selected field with type 'urls.userFilter' is not a param of struct 'nestedParams'",
+			func() {
+				FieldName(func(p *nestedParams) *userFilter { return &p.Filter })
+			},
+		)
+	})
+}
@@ -0,0 +1,107 @@
+package urls
+
+import (
+	"fmt"
+	"mime/multipart"
+	"reflect"
+)
+
+// File is the param type for file inputs of multipart forms.
+// It is only bound by SetStructWithFiles, and is never included in Path.Eval
+type File struct {
+	header *multipart.FileHeader
+}
+
+func NewFile(header *multipart.FileHeader) File {
+	return File{header: header}
+}
+
+// IsEmpty returns true if no file is uploaded
+func (f File) IsEmpty() bool {
+	return f.header == nil
+}
+
+func (f File) Header() *multipart.FileHeader {
+	return f.header
+}
+
+func (f File) Filename() string {
+	if f.header == nil {
+		return ""
+	}
+	return f.header.Filename
+}
+
+func (f File) Size() int64 {
+	if f.header == nil {
+		return 0
+	}
+	return f.header.Size
+}
+
+// ContentType returns the Content-Type sent by the client, it should not be trusted
+func (f File) ContentType() string {
+	if f.header == nil {
+		return ""
+	}
+	return f.header.Header.Get("Content-Type")
+}
+
+// Open opens the uploaded file, the caller must close it after use
+func (f File) Open() (multipart.File, error) {
+	if f.header == nil {
+		return nil, &FileError{Message: "no file is uploaded"}
+	}
+	return f.header.Open()
+}
+
+type FileError struct {
+	Message string
+}
+
+func (e *FileError) Error() string {
+	return e.Message
+}
+
+// SetStructWithFiles sets fields of type File, other fields are ignored
+func SetStructWithFiles(
+	obj any, updateFields []string,
+	fileFunc func(name string) *multipart.FileHeader,
+) error {
+	updateSet := map[string]struct{}{}
+	for _, jsonTag := range updateFields {
+		updateSet[jsonTag] = struct{}{}
+	}
+
+	objValuePtr := reflect.ValueOf(obj)
+	if objValuePtr.Kind() != reflect.Ptr {
+		return fmt.Errorf("input object type '%s' must be a pointer instead", objValuePtr.Type().String())
+	}
+
+	for jsonTag := range getAllJsonTagsOfValue(objValuePtr.Elem()) {
+		if jsonTag.err != nil {
+			return jsonTag.err
+		}
+		if !jsonTag.isFile {
+			continue
+		}
+
+		_, ok := updateSet[jsonTag.name]
+		if !ok {
+			continue
+		}
+
+		header := fileFunc(jsonTag.name)
+		if header == nil && len(jsonTag.bracketName) > 0 {
+			header = fileFunc(jsonTag.bracketName)
+		}
+		if header == nil {
+			continue
+		}
+		jsonTag.fieldValue.Set(reflect.ValueOf(NewFile(header)))
+	}
+
+	return nil
+}
+
+var fileType = reflect.TypeFor[File]()
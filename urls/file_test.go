@@ -0,0 +1,108 @@
+package urls
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type uploadParams struct {
+	ID     int64  `json:"id"`
+	Title  string `json:"title"`
+	Avatar File   `json:"avatar"`
+}
+
+func TestFile(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var f File
+		assert.Equal(t, true, f.IsEmpty())
+		assert.Equal(t, "", f.Filename())
+		assert.Equal(t, int64(0), f.Size())
+		assert.Equal(t, "", f.ContentType())
+
+		_, err := f.Open()
+		assert.Equal(t, &FileError{Message: "no file is uploaded"}, err)
+		assert.Equal(t, "no file is uploaded", err.Error())
+	})
+
+	t.Run("normal", func(t *testing.T) {
+		header := &multipart.FileHeader{
+			Filename: "avatar.png",
+			Size:     120,
+			Header: textproto.MIMEHeader{
+				"Content-Type": {"image/png"},
+			},
+		}
+		f := NewFile(header)
+		assert.Equal(t, false, f.IsEmpty())
+		assert.Equal(t, "avatar.png", f.Filename())
+		assert.Equal(t, int64(120), f.Size())
+		assert.Equal(t, "image/png", f.ContentType())
+		assert.Same(t, header, f.Header())
+	})
+}
+
+func TestSetStructWithFiles(t *testing.T) {
+	header := &multipart.FileHeader{Filename: "avatar.png"}
+
+	t.Run("normal", func(t *testing.T) {
+		var params uploadParams
+		err := SetStructWithFiles(&params, []string{"title", "avatar"}, func(name string) *multipart.FileHeader {
+			if name == "avatar" {
+				return header
+			}
+			return nil
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, uploadParams{Avatar: NewFile(header)}, params)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		var params uploadParams
+		err := SetStructWithFiles(&params, []string{"avatar"}, func(name string) *multipart.FileHeader {
+			return nil
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, uploadParams{}, params)
+	})
+
+	t.Run("not in update fields", func(t *testing.T) {
+		var params uploadParams
+		err := SetStructWithFiles(&params, []string{"title"}, func(name string) *multipart.FileHeader {
+			return header
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, uploadParams{}, params)
+	})
+
+	t.Run("obj not a pointer", func(t *testing.T) {
+		var params uploadParams
+		err := SetStructWithFiles(params, []string{"avatar"}, func(name string) *multipart.FileHeader {
+			return header
+		})
+		assert.Equal(t, errors.New("input object type 'urls.uploadParams' must be a pointer instead"), err)
+	})
+}
+
+func TestPath__With_File(t *testing.T) {
+	p := New[uploadParams]("/users/{id}/upload")
+	assert.Equal(t, []string{"title", "avatar"}, p.GetNonPathParams())
+
+	newURL := p.Eval(uploadParams{
+		ID:     11,
+		Title:  "hello",
+		Avatar: NewFile(&multipart.FileHeader{Filename: "avatar.png"}),
+	})
+	assert.Equal(t, "/users/11/upload?title=hello", newURL)
+
+	// string values are not bound to file fields
+	var params uploadParams
+	err := SetStructWithValues(&params, []string{"title", "avatar"}, func(name string) string {
+		return "value01"
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uploadParams{Title: "value01"}, params)
+}
@@ -0,0 +1,99 @@
+package urls
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/QuangTung97/weblib/null"
+)
+
+// ---------------------------------------------------------------------------
+// Internal Implementation
+// ---------------------------------------------------------------------------
+
+// fieldTag is parsed from the struct tags of a field.
+// The `url` tag takes precedence over the `json` tag, with syntax:
+//
+//	url:"name,required,omitempty,default=value"
+//
+// default= must be the last option, its value can contain commas.
+// For string fields, an explicitly empty param (e.g. ?q=) is kept as empty instead of using the default.
+// Options of the `json` tag are ignored, and "-" means the field is skipped
+type fieldTag struct {
+	name    string
+	skipped bool
+	missing bool // both url and json tags are missing
+
+	options tagOptions
+}
+
+type tagOptions struct {
+	required     bool
+	omitEmpty    bool
+	defaultValue null.Null[string]
+}
+
+func parseFieldTag(field reflect.StructField, structType reflect.Type) (fieldTag, error) {
+	urlTag, hasURLTag := field.Tag.Lookup("url")
+	if hasURLTag {
+		return parseURLTag(urlTag, field, structType)
+	}
+
+	jsonTag := field.Tag.Get("json")
+	if len(jsonTag) == 0 {
+		return fieldTag{missing: true}, nil
+	}
+	if jsonTag == "-" {
+		return fieldTag{skipped: true}, nil
+	}
+
+	name, _, _ := strings.Cut(jsonTag, ",")
+	if len(name) == 0 {
+		name = field.Name
+	}
+	return fieldTag{name: name}, nil
+}
+
+func parseURLTag(urlTag string, field reflect.StructField, structType reflect.Type) (fieldTag, error) {
+	if urlTag == "-" {
+		return fieldTag{skipped: true}, nil
+	}
+
+	name, options, _ := strings.Cut(urlTag, ",")
+	if len(name) == 0 {
+		name = field.Name
+	}
+
+	result := fieldTag{name: name}
+	for len(options) > 0 {
+		if value, ok := strings.CutPrefix(options, "default="); ok {
+			result.options.defaultValue = null.New(value)
+			break
+		}
+
+		var option string
+		option, options, _ = strings.Cut(options, ",")
+
+		switch option {
+		case "required":
+			result.options.required = true
+		case "omitempty":
+			result.options.omitEmpty = true
+		default:
+			return fieldTag{}, fmt.Errorf(
+				"unknown option '%s' in url tag of field '%s' in struct '%s'",
+				option, field.Name, structType.Name(),
+			)
+		}
+	}
+
+	if result.options.required && result.options.defaultValue.Valid {
+		return fieldTag{}, fmt.Errorf(
+			"field '%s' in struct '%s' can not be both required and have default value",
+			field.Name, structType.Name(),
+		)
+	}
+
+	return result, nil
+}
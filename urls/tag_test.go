@@ -0,0 +1,181 @@
+package urls
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/null"
+)
+
+type tagParams struct {
+	ID       int               `json:"id,omitempty"`
+	Search   string            `json:"search_text" url:"q"`
+	Page     int               `json:"page" url:"page,default=1"`
+	Sort     string            `url:"sort,omitempty,default=name,asc"`
+	Token    string            `json:"token" url:"token,required"`
+	Internal string            `json:"-"`
+	Hidden   string            `json:"hidden" url:"-"`
+	Note     null.Null[string] `json:",omitempty"`
+}
+
+func TestPath__Tag_Options(t *testing.T) {
+	t.Run("get params", func(t *testing.T) {
+		p := New[tagParams]("/users/{id}")
+		assert.Equal(t, []string{"id"}, p.GetPathParams())
+		assert.Equal(t, []string{"q", "page", "sort", "token", "Note"}, p.GetNonPathParams())
+	})
+
+	t.Run("eval", func(t *testing.T) {
+		p := New[tagParams]("/users/{id}")
+		newURL := p.Eval(tagParams{
+			ID:       11,
+			Search:   "hello",
+			Page:     2,
+			Sort:     "age",
+			Token:    "abc",
+			Internal: "internal",
+			Hidden:   "hidden",
+			Note:     null.New("note"),
+		})
+		assert.Equal(t, "/users/11?Note=note&page=2&q=hello&sort=age&token=abc", newURL)
+	})
+
+	t.Run("eval with default values", func(t *testing.T) {
+		p := New[tagParams]("/users/{id}")
+
+		// equal to the default values
+		newURL := p.Eval(tagParams{ID: 11, Page: 1, Sort: "name,asc"})
+		assert.Equal(t, "/users/11", newURL)
+
+		// zero value is different from the default value
+		newURL = p.Eval(tagParams{ID: 11})
+		assert.Equal(t, "/users/11?page=0", newURL)
+	})
+
+	t.Run("set values", func(t *testing.T) {
+		values := map[string]string{
+			"q":           "hello",
+			"search_text": "not used",
+			"token":       "abc",
+			"Internal":    "internal",
+			"hidden":      "hidden",
+		}
+
+		var params tagParams
+		err := SetStructWithValues(
+			&params, New[tagParams]("/users/{id}").GetNonPathParams(),
+			func(name string) string {
+				return values[name]
+			},
+		)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, tagParams{
+			Search: "hello",
+			Page:   1,
+			Sort:   "name,asc",
+			Token:  "abc",
+		}, params)
+	})
+
+	t.Run("set values, missing required", func(t *testing.T) {
+		var params tagParams
+		err := SetStructWithValues(&params, []string{"q", "token"}, func(name string) string {
+			return ""
+		})
+		assert.Equal(t, errors.New("missing required param 'token'"), err)
+	})
+
+	t.Run("set optional values, then check required", func(t *testing.T) {
+		var params tagParams
+		err := SetStructWithOptionalValueList(&params, []string{"q", "token"}, func(name string) []string {
+			return nil
+		})
+		assert.Equal(t, nil, err)
+
+		fields := []string{"q", "token"}
+		assert.Equal(t, errors.New("missing required param 'token'"), CheckRequiredParams(&params, fields))
+
+		params.Token = "abc"
+		assert.Equal(t, nil, CheckRequiredParams(&params, fields))
+	})
+
+	t.Run("eval then set, empty string with default value", func(t *testing.T) {
+		type searchParams struct {
+			Q    string `url:"q,default=abc"`
+			Page int    `url:"page,default=1"`
+		}
+
+		p := New[searchParams]("/search")
+		for _, input := range []searchParams{
+			{Q: "", Page: 0},
+			{Q: "abc", Page: 1},
+			{Q: "hello", Page: 3},
+		} {
+			newURL := p.Eval(input)
+
+			u, err := url.Parse(newURL)
+			assert.Equal(t, nil, err)
+			query := u.Query()
+
+			var params searchParams
+			err = SetStructWithValueList(&params, p.GetNonPathParams(), func(name string) []string {
+				return query[name]
+			})
+			assert.Equal(t, nil, err)
+			assert.Equal(t, input, params, newURL)
+		}
+
+		assert.Equal(t, "/search?page=0&q=", p.Eval(searchParams{}))
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		type invalidStruct struct {
+			Name string `url:"name,unknown"`
+		}
+		assert.PanicsWithValue(t,
+			"unknown option 'unknown' in url tag of field 'Name' in struct 'invalidStruct'",
+			func() {
+				New[invalidStruct]("/home")
+			},
+		)
+	})
+
+	t.Run("invalid default value", func(t *testing.T) {
+		type invalidStruct struct {
+			Page int `url:"page,default=abc"`
+		}
+		assert.PanicsWithValue(t,
+			"can not set value 'abc' to field 'page' with type 'int'",
+			func() {
+				New[invalidStruct]("/home")
+			},
+		)
+	})
+
+	t.Run("default value of slice", func(t *testing.T) {
+		type invalidStruct struct {
+			Tags []string `url:"tag,default=a"`
+		}
+		assert.PanicsWithValue(t,
+			"default value is not supported for field 'tag' with type '[]string'",
+			func() {
+				New[invalidStruct]("/home")
+			},
+		)
+	})
+
+	t.Run("required with default value", func(t *testing.T) {
+		type invalidStruct struct {
+			Page int `url:"page,required,default=1"`
+		}
+		assert.PanicsWithValue(t,
+			"field 'Page' in struct 'invalidStruct' can not be both required and have default value",
+			func() {
+				New[invalidStruct]("/home")
+			},
+		)
+	})
+}
@@ -5,8 +5,11 @@ import (
 	"iter"
 	"net/url"
 	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/QuangTung97/weblib/null"
 )
@@ -18,20 +21,38 @@ type Path[T any] struct {
 func New[T any](pattern string) Path[T] {
 	var empty T
 
-	jsonTagSet := map[string]struct{}{}
-	for jsonTag := range getAllJsonTags(empty) {
+	structName := reflect.TypeOf(empty).Name()
+
+	jsonTagSet := map[string]jsonTagValue{}
+	usedNames := map[string]struct{}{} // including bracketed names of nested struct fields
+	for jsonTag := range getAllJsonTagsOfValue(reflect.ValueOf(&empty).Elem()) {
 		if jsonTag.err != nil {
 			panic(jsonTag.err.Error())
 		}
-		jsonTagSet[jsonTag.name] = struct{}{}
+		if err := checkDefaultValue(jsonTag); err != nil {
+			panic(err.Error())
+		}
+
+		for _, name := range []string{jsonTag.name, jsonTag.bracketName} {
+			if len(name) == 0 {
+				continue
+			}
+			if _, existed := usedNames[name]; existed {
+				panic(fmt.Sprintf("duplicated param name '%s' in struct '%s'", name, structName))
+			}
+			usedNames[name] = struct{}{}
+		}
+		jsonTagSet[jsonTag.name] = jsonTag
 	}
 
 	for param := range findPathParams(pattern) {
-		_, ok := jsonTagSet[param.name]
+		jsonTag, ok := jsonTagSet[param.name]
 		if !ok {
-			structName := reflect.TypeOf(empty).Name()
 			panic(fmt.Sprintf("missing json tag '%s' in struct '%s'", param.name, structName))
 		}
+		if jsonTag.isSlice {
+			panic(fmt.Sprintf("path param '%s' in struct '%s' can not be a slice", param.name, structName))
+		}
 	}
 
 	return Path[T]{
@@ -62,7 +83,13 @@ func (p Path[T]) Eval(params T) string {
 
 	queryParams := url.Values{}
 	for _, jsonTag := range jsonTagMap {
-		if jsonTag.isZero {
+		if jsonTag.isFile || jsonTag.isOmitted() {
+			continue
+		}
+		if jsonTag.isSlice {
+			for _, value := range jsonTag.values {
+				queryParams.Add(jsonTag.name, value)
+			}
 			continue
 		}
 		queryParams.Add(jsonTag.name, jsonTag.value)
@@ -79,6 +106,16 @@ func (p Path[T]) GetPattern() string {
 	return p.pattern
 }
 
+// Match checks whether the path of the url matches the pattern, query and fragment are ignored.
+// Path params match any non-empty segment
+func (p Path[T]) Match(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return getPatternRegexp(p.pattern).MatchString(u.Path)
+}
+
 func (p Path[T]) GetPathParams() []string {
 	var result []string
 	for param := range findPathParams(p.pattern) {
@@ -105,9 +142,104 @@ func (p Path[T]) GetNonPathParams() []string {
 	return result
 }
 
+// GetNonPathValues returns string values of non path params, used for filling inputs of forms.
+// Zero values and files are excluded, slices have one value for each element
+func (p Path[T]) GetNonPathValues(params T) url.Values {
+	return p.getNonPathValues(params, false)
+}
+
+// GetNonPathValuesWithZero is similar to GetNonPathValues, but zero values of non-null scalars are included,
+// e.g. age=0 for the re-rendered input of an invalid form
+func (p Path[T]) GetNonPathValuesWithZero(params T) url.Values {
+	return p.getNonPathValues(params, true)
+}
+
+func (p Path[T]) getNonPathValues(params T, withZero bool) url.Values {
+	pathParamSet := map[string]struct{}{}
+	for param := range findPathParams(p.pattern) {
+		pathParamSet[param.name] = struct{}{}
+	}
+
+	result := url.Values{}
+	for jsonTag := range getAllJsonTags(params) {
+		if _, existed := pathParamSet[jsonTag.name]; existed {
+			continue
+		}
+		if jsonTag.isFile {
+			continue
+		}
+		if jsonTag.isZero && !(withZero && jsonTag.isNonNullScalar()) {
+			continue
+		}
+		if jsonTag.isSlice {
+			result[jsonTag.name] = jsonTag.values
+			continue
+		}
+		result.Set(jsonTag.name, jsonTag.value)
+	}
+	return result
+}
+
 func SetStructWithValues(
 	obj any, updateFields []string,
 	valueFunc func(name string) string,
+) error {
+	return SetStructWithValueList(obj, updateFields, func(name string) []string {
+		valueStr := valueFunc(name)
+		if len(valueStr) == 0 {
+			return nil
+		}
+		return []string{valueStr}
+	})
+}
+
+// SetStructWithValueList is similar to SetStructWithValues, but supports repeated values for slice fields.
+// Non-slice fields use the first value, empty values are ignored
+func SetStructWithValueList(
+	obj any, updateFields []string,
+	valuesFunc func(name string) []string,
+) error {
+	return setStructWithValueList(obj, updateFields, valuesFunc, true)
+}
+
+// SetStructWithOptionalValueList is similar to SetStructWithValueList, but does not check required params.
+// It is used when params can also be set from other sources (e.g. a json request body),
+// the required params should be checked using CheckRequiredParams after all sources are bound
+func SetStructWithOptionalValueList(
+	obj any, updateFields []string,
+	valuesFunc func(name string) []string,
+) error {
+	return setStructWithValueList(obj, updateFields, valuesFunc, false)
+}
+
+// CheckRequiredParams checks that the required params in updateFields have non-zero values
+func CheckRequiredParams(obj any, updateFields []string) error {
+	updateSet := map[string]struct{}{}
+	for _, jsonTag := range updateFields {
+		updateSet[jsonTag] = struct{}{}
+	}
+
+	for jsonTag := range getAllJsonTagsOfValue(reflect.Indirect(reflect.ValueOf(obj))) {
+		if jsonTag.err != nil {
+			return jsonTag.err
+		}
+
+		_, ok := updateSet[jsonTag.name]
+		if !ok || jsonTag.isFile {
+			continue
+		}
+
+		if jsonTag.tagOptions.required && jsonTag.isZero {
+			return fmt.Errorf("missing required param '%s'", jsonTag.name)
+		}
+	}
+	return nil
+}
+
+func setStructWithValueList(
+	obj any, updateFields []string,
+	valuesFunc func(name string) []string,
+	checkRequired bool,
 ) error {
 	updateSet := map[string]struct{}{}
 	for _, jsonTag := range updateFields {
@@ -131,16 +263,41 @@ func SetStructWithValues(
 			continue
 		}
 
-		valueStr := valueFunc(jsonTag.name)
-		if len(valueStr) == 0 {
+		// files are set by SetStructWithFiles
+		if jsonTag.isFile {
 			continue
 		}
 
-		if ok := updateValueFromString(jsonTag.fieldValue, valueStr); !ok {
-			return fmt.Errorf(
-				"can not set value '%s' to field '%s' with type '%s'",
-				valueStr, jsonTag.name, jsonTag.fieldValue.Type().String(),
-			)
+		rawValues := valuesFunc(jsonTag.name)
+		if len(nonEmptyValues(rawValues)) == 0 && len(jsonTag.bracketName) > 0 {
+			// fallback to bracketed key of nested struct fields
+			if bracketValues := valuesFunc(jsonTag.bracketName); len(bracketValues) > 0 {
+				rawValues = bracketValues
+			}
+		}
+
+		values := nonEmptyValues(rawValues)
+		if len(values) == 0 && jsonTag.isExplicitEmptyString(rawValues) {
+			// Path.Eval encodes empty strings of fields with default values as empty params
+			jsonTag.fieldValue.SetZero()
+			continue
+		}
+		if len(values) == 0 && jsonTag.tagOptions.defaultValue.Valid {
+			values = []string{jsonTag.tagOptions.defaultValue.Data}
+		}
+		if len(values) == 0 && jsonTag.tagOptions.required && checkRequired {
+			return fmt.Errorf("missing required param '%s'", jsonTag.name)
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		if !jsonTag.isSlice {
+			values = values[:1]
+		}
+
+		if err := setFieldFromStrings(jsonTag, values); err != nil {
+			return err
 		}
 	}
 
@@ -151,6 +308,83 @@ func SetStructWithValues(
 // Internal Implementation
 // ---------------------------------------------------------------------------
 
+// patternRegexpCache maps patterns to *regexp.Regexp used by Path.Match
+var patternRegexpCache sync.Map
+
+// getPatternRegexp returns the regexp matching paths of the pattern, the regexp is compiled once per pattern
+func getPatternRegexp(pattern string) *regexp.Regexp {
+	cached, ok := patternRegexpCache.Load(pattern)
+	if ok {
+		return cached.(*regexp.Regexp)
+	}
+
+	var buf strings.Builder
+	buf.WriteString("^")
+	lastIndex := 0
+	for param := range findPathParams(pattern) {
+		buf.WriteString(regexp.QuoteMeta(pattern[lastIndex:param.begin]))
+		buf.WriteString("[^/]+")
+		lastIndex = param.end
+	}
+	buf.WriteString(regexp.QuoteMeta(pattern[lastIndex:]))
+	buf.WriteString("$")
+
+	cached, _ = patternRegexpCache.LoadOrStore(pattern, regexp.MustCompile(buf.String()))
+	return cached.(*regexp.Regexp)
+}
+
+// isOmitted checks whether the field is not included in the query of Path.Eval.
+// Zero values are still included when the default value is different
+func (v jsonTagValue) isOmitted() bool {
+	if v.isZero && (v.tagOptions.omitEmpty || !v.tagOptions.defaultValue.Valid) {
+		return true
+	}
+	if v.tagOptions.defaultValue.Valid && v.value == v.tagOptions.defaultValue.Data {
+		return true
+	}
+	return false
+}
+
+func (v jsonTagValue) isNonNullScalar() bool {
+	if v.isSlice {
+		return false
+	}
+	_, isNull := null.IsNullType(v.fieldValue)
+	return !isNull
+}
+
+// isExplicitEmptyString checks whether an empty value is present for a string field with default value,
+// the empty string is kept instead of using the default value
+func (v jsonTagValue) isExplicitEmptyString(rawValues []string) bool {
+	if !v.tagOptions.defaultValue.Valid || len(rawValues) == 0 {
+		return false
+	}
+	return v.fieldValue.Kind() == reflect.String
+}
+
+// checkDefaultValue checks the default value can be set to the field, the field value must be settable
+func checkDefaultValue(jsonTag jsonTagValue) error {
+	if !jsonTag.tagOptions.defaultValue.Valid {
+		return nil
+	}
+
+	fieldVal := jsonTag.fieldValue
+	if _, isNull := null.IsNullType(fieldVal); isNull || jsonTag.isSlice || jsonTag.isFile {
+		return fmt.Errorf(
+			"default value is not supported for field '%s' with type '%s'",
+			jsonTag.name, fieldVal.Type().String(),
+		)
+	}
+	return setFieldFromStrings(jsonTag, []string{jsonTag.tagOptions.defaultValue.Data})
+}
+
+func nonEmptyValues(values []string) []string {
+	return slices.DeleteFunc(
+		slices.Clone(values),
+		func(s string) bool { return len(s) == 0 },
+	)
+}
+
 type pathParam struct {
 	name  string
 	begin int
@@ -193,10 +427,19 @@ func findPathParams(pattern string) iter.Seq[pathParam] {
 }
 
 type jsonTagValue struct {
-	name   string
-	value  string
-	isZero bool
-	err    error
+	name        string
+	bracketName string // only for fields of nested structs, e.g. filter[status]
+	value       string
+	isZero      bool
+	isFile      bool
+	err         error
+
+	isSlice bool
+	values  []string // only for slice fields
+
+	tagOptions tagOptions
+	options    fieldOptions
+	rules      []validateRule
 
 	fieldValue reflect.Value
 }
@@ -216,46 +459,164 @@ func getAllJsonTagsOfValue(value reflect.Value) iter.Seq[jsonTagValue] {
 	}
 
 	return func(yield func(jsonTagValue) bool) {
-		for index := range value.NumField() {
-			fieldType := valType.Field(index)
-			fieldVal := value.Field(index)
-
-			jsonTag := fieldType.Tag.Get("json")
-			if len(jsonTag) == 0 {
-				err := fmt.Errorf(
-					"missing json tag of field '%s' in struct '%s'",
-					fieldType.Name, valType.Name(),
-				)
-				yield(jsonTagValue{err: err})
-				return
+		walkJsonTagsOfStruct(value, jsonTagPrefix{}, yield)
+	}
+}
+
+// jsonTagPrefix is the key prefix of fields inside named nested structs
+type jsonTagPrefix struct {
+	dotted    string // e.g. "filter."
+	bracketed string // e.g. "filter"
+}
+
+func (p jsonTagPrefix) getNames(jsonTag string) (name string, bracketName string) {
+	if len(p.bracketed) == 0 {
+		return jsonTag, ""
+	}
+	return p.dotted + jsonTag, p.bracketed + "[" + jsonTag + "]"
+}
+
+// walkJsonTagsOfStruct returns false when the iteration is stopped.
+// Anonymous embedded structs are flattened, named nested structs use prefixed keys
+func walkJsonTagsOfStruct(
+	value reflect.Value, prefix jsonTagPrefix,
+	yield func(jsonTagValue) bool,
+) bool {
+	valType := value.Type()
+
+	for index := range value.NumField() {
+		fieldType := valType.Field(index)
+		fieldVal := value.Field(index)
+
+		tag, err := parseFieldTag(fieldType, valType)
+		if err != nil {
+			yield(jsonTagValue{err: err})
+			return false
+		}
+		if tag.skipped {
+			continue
+		}
+
+		options := getFieldOptions(fieldType)
+
+		rules, err := getValidateRules(valType, index)
+		if err != nil {
+			yield(jsonTagValue{err: err})
+			return false
+		}
+
+		if tag.missing && fieldType.Anonymous && isNestedStruct(fieldType.Type, options) {
+			if !walkJsonTagsOfStruct(fieldVal, prefix, yield) {
+				return false
 			}
+			continue
+		}
+
+		if tag.missing {
+			err := fmt.Errorf(
+				"missing json tag of field '%s' in struct '%s'",
+				fieldType.Name, valType.Name(),
+			)
+			yield(jsonTagValue{err: err})
+			return false
+		}
+
+		name, bracketName := prefix.getNames(tag.name)
+
+		if isNestedStruct(fieldType.Type, options) {
+			nestedPrefix := jsonTagPrefix{
+				dotted:    name + ".",
+				bracketed: name,
+			}
+			if len(bracketName) > 0 {
+				nestedPrefix.bracketed = bracketName
+			}
+
+			if !walkJsonTagsOfStruct(fieldVal, nestedPrefix, yield) {
+				return false
+			}
+			continue
+		}
 
-			valueStr, ok := reflectValueToString(fieldVal)
-			if !ok {
-				err := fmt.Errorf(
-					"not support type '%s' of field '%s' in struct '%s'",
-					fieldType.Type.String(), fieldType.Name, valType.Name(),
-				)
-				yield(jsonTagValue{err: err})
-				return
+		if isSupportedSlice(fieldType.Type, options) {
+			values := make([]string, 0, fieldVal.Len())
+			for i := range fieldVal.Len() {
+				elemStr, _ := reflectValueToString(fieldVal.Index(i), options)
+				values = append(values, elemStr)
 			}
 
 			tagVal := jsonTagValue{
-				name:   jsonTag,
-				value:  valueStr,
-				isZero: fieldVal.IsZero(),
+				name:        name,
+				bracketName: bracketName,
+				isZero:      fieldVal.Len() == 0,
+
+				isSlice: true,
+				values:  values,
 
+				tagOptions: tag.options,
+				rules:      rules,
+				options:    options,
 				fieldValue: fieldVal,
 			}
-
 			if !yield(tagVal) {
-				return
+				return false
 			}
+			continue
+		}
+
+		valueStr, ok := reflectValueToString(fieldVal, options)
+		if !ok {
+			err := fmt.Errorf(
+				"not support type '%s' of field '%s' in struct '%s'",
+				fieldType.Type.String(), fieldType.Name, valType.Name(),
+			)
+			yield(jsonTagValue{err: err})
+			return false
+		}
+
+		tagVal := jsonTagValue{
+			name:        name,
+			bracketName: bracketName,
+			value:       valueStr,
+			isZero:      fieldVal.IsZero(),
+			isFile:      fieldVal.Type() == fileType,
+
+			tagOptions: tag.options,
+			rules:      rules,
+			options:    options,
+			fieldValue: fieldVal,
+		}
+
+		if !yield(tagVal) {
+			return false
 		}
 	}
+
+	return true
 }
 
-func reflectValueToString(val reflect.Value) (string, bool) {
+// isNestedStruct checks whether the struct type is a group of params instead of a single value
+func isNestedStruct(fieldType reflect.Type, options fieldOptions) bool {
+	if fieldType.Kind() != reflect.Struct || fieldType == fileType {
+		return false
+	}
+	_, ok := reflectValueToString(reflect.New(fieldType).Elem(), options)
+	return !ok
+}
+
+func reflectValueToString(val reflect.Value, options fieldOptions) (string, bool) {
+	if val.Type() == fileType {
+		header := val.Field(0)
+		if header.IsNil() {
+			return "", true
+		}
+		return header.Elem().FieldByName("Filename").String(), true
+	}
+
+	if str, handled := specialValueToString(val, options); handled {
+		return str, true
+	}
+
 	switch val.Kind() {
 	case reflect.Bool:
 		return strconv.FormatBool(val.Bool()), true
@@ -271,6 +632,9 @@ func reflectValueToString(val reflect.Value) (string, bool) {
 		reflect.Uint32, reflect.Uint64, reflect.Uintptr:
 		return strconv.FormatUint(val.Uint(), 10), true
 
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'f', -1, val.Type().Bits()), true
+
 	default:
 		output, ok := null.IsNullType(val)
 		if !ok {
@@ -278,14 +642,57 @@ func reflectValueToString(val reflect.Value) (string, bool) {
 		}
 
 		if output.NonNull {
-			return reflectValueToString(output.DataField)
+			return reflectValueToString(output.DataField, options)
 		}
 
 		return "null", true
 	}
 }
 
-func updateValueFromString(val reflect.Value, str string) bool {
+// isSupportedSlice checks the slice element type is a supported scalar type
+func isSupportedSlice(sliceType reflect.Type, options fieldOptions) bool {
+	if sliceType.Kind() != reflect.Slice {
+		return false
+	}
+	elemType := sliceType.Elem()
+	if elemType.Kind() == reflect.Slice || elemType == fileType {
+		return false
+	}
+	_, ok := reflectValueToString(reflect.New(elemType).Elem(), options)
+	return ok
+}
+
+func setFieldFromStrings(jsonTag jsonTagValue, values []string) error {
+	fieldVal := jsonTag.fieldValue
+
+	if !jsonTag.isSlice {
+		if ok := updateValueFromString(fieldVal, values[0], jsonTag.options); !ok {
+			return fmt.Errorf(
+				"can not set value '%s' to field '%s' with type '%s'",
+				values[0], jsonTag.name, fieldVal.Type().String(),
+			)
+		}
+		return nil
+	}
+
+	newSlice := reflect.MakeSlice(fieldVal.Type(), len(values), len(values))
+	for i, valueStr := range values {
+		if ok := updateValueFromString(newSlice.Index(i), valueStr, jsonTag.options); !ok {
+			return fmt.Errorf(
+				"can not set value '%s' to field '%s' with type '%s'",
+				valueStr, jsonTag.name, fieldVal.Type().String(),
+			)
+		}
+	}
+	fieldVal.Set(newSlice)
+	return nil
+}
+
+func updateValueFromString(val reflect.Value, str string, options fieldOptions) bool {
+	if ok, handled := specialValueFromString(val, str, options); handled {
+		return ok
+	}
+
 	switch val.Kind() {
 	case reflect.Bool:
 		b, err := strconv.ParseBool(str)
@@ -317,13 +724,21 @@ func updateValueFromString(val reflect.Value, str string) bool {
 		val.SetUint(num)
 		return true
 
+	case reflect.Float32, reflect.Float64:
+		num, err := strconv.ParseFloat(str, val.Type().Bits())
+		if err != nil {
+			return false
+		}
+		val.SetFloat(num)
+		return true
+
 	default:
 		output, ok := null.IsNullType(val)
 		if !ok {
 			return false
 		}
 
-		if ok := updateValueFromString(output.DataField, str); !ok {
+		if ok := updateValueFromString(output.DataField, str, options); !ok {
 			return false
 		}
 		output.ValidField.SetBool(true)
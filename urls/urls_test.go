@@ -2,6 +2,7 @@ package urls
 
 import (
 	"errors"
+	"net/url"
 	"reflect"
 	"slices"
 	"testing"
@@ -369,3 +370,275 @@ func TestSetStructWithValues(t *testing.T) {
 		assert.Equal(t, errors.New("not support type '*int' of field 'Age' in struct 'testEntity'"), err)
 	})
 }
+
+type sliceParams struct {
+	ID     int64             `json:"id"`
+	Tags   []string          `json:"tag"`
+	Users  []int64           `json:"user"`
+	Scores []null.Null[int]  `json:"score"`
+	Flags  []bool            `json:"flag"`
+	Name   null.Null[string] `json:"name"`
+}
+
+func TestPath__Slice_Params(t *testing.T) {
+	t.Run("eval", func(t *testing.T) {
+		p := New[sliceParams]("/users/{id}")
+		newURL := p.Eval(sliceParams{
+			ID:    11,
+			Tags:  []string{"b", "a"},
+			Users: []int64{3, 1, 2},
+		})
+		assert.Equal(t, "/users/11?tag=b&tag=a&user=3&user=1&user=2", newURL)
+	})
+
+	t.Run("eval empty slices", func(t *testing.T) {
+		p := New[sliceParams]("/users/{id}")
+		newURL := p.Eval(sliceParams{
+			ID:    11,
+			Users: []int64{},
+		})
+		assert.Equal(t, "/users/11", newURL)
+	})
+
+	t.Run("path param can not be slice", func(t *testing.T) {
+		assert.PanicsWithValue(t, "path param 'tag' in struct 'sliceParams' can not be a slice", func() {
+			New[sliceParams]("/users/{tag}")
+		})
+	})
+
+	t.Run("not support slice of slice", func(t *testing.T) {
+		type invalidStruct struct {
+			Values [][]string `json:"values"`
+		}
+		assert.PanicsWithValue(t,
+			"not support type '[][]string' of field 'Values' in struct 'invalidStruct'",
+			func() {
+				New[invalidStruct]("/home")
+			},
+		)
+	})
+}
+
+func TestSetStructWithValueList(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		values := url.Values{
+			"id":    {"12", "13"},
+			"tag":   {"a", "", "b"},
+			"user":  {"3", "1"},
+			"score": {"5"},
+			"flag":  {"true", "false"},
+			"name":  {"", "hello"},
+		}
+
+		var params sliceParams
+		err := SetStructWithValueList(
+			&params, []string{"id", "tag", "user", "score", "flag", "name"},
+			func(name string) []string {
+				return values[name]
+			},
+		)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, sliceParams{
+			ID:     12,
+			Tags:   []string{"a", "b"},
+			Users:  []int64{3, 1},
+			Scores: []null.Null[int]{null.New(5)},
+			Flags:  []bool{true, false},
+			Name:   null.New("hello"),
+		}, params)
+
+		// empty values not replace the old ones
+		assert.Equal(t, []string{"a", "", "b"}, values["tag"])
+	})
+
+	t.Run("replace old slice", func(t *testing.T) {
+		params := sliceParams{
+			Tags: []string{"old"},
+		}
+		err := SetStructWithValueList(&params, []string{"tag"}, func(name string) []string {
+			return []string{"new01", "new02"}
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, sliceParams{Tags: []string{"new01", "new02"}}, params)
+	})
+
+	t.Run("invalid element", func(t *testing.T) {
+		var params sliceParams
+		err := SetStructWithValueList(&params, []string{"user"}, func(name string) []string {
+			return []string{"1", "xx"}
+		})
+		assert.Equal(t, errors.New("can not set value 'xx' to field 'user' with type '[]int64'"), err)
+		assert.Equal(t, sliceParams{}, params)
+	})
+
+	t.Run("with single value func", func(t *testing.T) {
+		var params sliceParams
+		err := SetStructWithValues(&params, []string{"tag"}, func(name string) string {
+			return "single"
+		})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, sliceParams{Tags: []string{"single"}}, params)
+	})
+}
+
+type paginationParams struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+type userFilter struct {
+	Status string   `json:"status"`
+	Roles  []string `json:"role"`
+	Range  struct {
+		From int `json:"from"`
+		To   int `json:"to"`
+	} `json:"range"`
+}
+
+type nestedParams struct {
+	paginationParams
+	ID     int        `json:"id"`
+	Filter userFilter `json:"filter"`
+}
+
+func TestPath__Nested_Params(t *testing.T) {
+	t.Run("get params", func(t *testing.T) {
+		p := New[nestedParams]("/teams/{id}")
+		assert.Equal(t, []string{"id"}, p.GetPathParams())
+		assert.Equal(t, []string{
+			"page", "page_size",
+			"filter.status", "filter.role", "filter.range.from", "filter.range.to",
+		}, p.GetNonPathParams())
+	})
+
+	t.Run("eval", func(t *testing.T) {
+		p := New[nestedParams]("/teams/{id}")
+
+		params := nestedParams{ID: 11}
+		params.Page = 2
+		params.Filter.Status = "active"
+		params.Filter.Roles = []string{"admin", "owner"}
+		params.Filter.Range.To = 20
+
+		assert.Equal(t,
+			"/teams/11?filter.range.to=20&filter.role=admin&filter.role=owner&filter.status=active&page=2",
+			p.Eval(params),
+		)
+	})
+
+	t.Run("set values with dotted and bracketed keys", func(t *testing.T) {
+		values := url.Values{
+			"page":                {"3"},
+			"filter.status":       {"active"},
+			"filter[role]":        {"admin", "member"},
+			"filter[range][to]":   {"30"},
+			"filter.range.from":   {""},
+			"filter[range][from]": {"10"},
+		}
+
+		p := New[nestedParams]("/teams/{id}")
+
+		var params nestedParams
+		err := SetStructWithValueList(&params, p.GetNonPathParams(), func(name string) []string {
+			return values[name]
+		})
+		assert.Equal(t, nil, err)
+
+		expected := nestedParams{}
+		expected.Page = 3
+		expected.Filter.Status = "active"
+		expected.Filter.Roles = []string{"admin", "member"}
+		expected.Filter.Range.From = 10
+		expected.Filter.Range.To = 30
+		assert.Equal(t, expected, params)
+	})
+
+	t.Run("missing json tag in nested struct", func(t *testing.T) {
+		type innerStruct struct {
+			Name string
+		}
+		type invalidStruct struct {
+			Inner innerStruct `json:"inner"`
+		}
+		assert.PanicsWithValue(t,
+			"missing json tag of field 'Name' in struct 'innerStruct'",
+			func() {
+				New[invalidStruct]("/home")
+			},
+		)
+	})
+
+	t.Run("duplicated name with embedded struct", func(t *testing.T) {
+		type invalidStruct struct {
+			paginationParams
+			Page   int    `json:"page"`
+			Status string `json:"status"`
+		}
+		assert.PanicsWithValue(t,
+			"duplicated param name 'page' in struct 'invalidStruct'",
+			func() {
+				New[invalidStruct]("/home")
+			},
+		)
+	})
+
+	t.Run("duplicated name after prefixing", func(t *testing.T) {
+		type invalidStruct struct {
+			Filter       userFilter `json:"filter"`
+			FilterStatus string     `url:"filter.status"`
+		}
+		assert.PanicsWithValue(t,
+			"duplicated param name 'filter.status' in struct 'invalidStruct'",
+			func() {
+				New[invalidStruct]("/home")
+			},
+		)
+	})
+}
+
+func TestPath_GetNonPathValues(t *testing.T) {
+	p := New[sliceParams]("/users/{id}")
+	values := p.GetNonPathValues(sliceParams{
+		ID:    11,
+		Tags:  []string{"a", "b"},
+		Flags: []bool{},
+		Name:  null.New("hello"),
+	})
+	assert.Equal(t, url.Values{
+		"tag":  {"a", "b"},
+		"name": {"hello"},
+	}, values)
+
+	// zero values of non-null scalars are included
+	values = New[testParams]("/users/{id}").GetNonPathValuesWithZero(testParams{ID: 11, Name: "hello"})
+	assert.Equal(t, url.Values{
+		"name": {"hello"},
+		"age":  {"0"},
+		"val":  {""},
+	}, values)
+
+	values = p.GetNonPathValuesWithZero(sliceParams{ID: 11})
+	assert.Equal(t, url.Values{}, values)
+}
+
+func TestPath_Match(t *testing.T) {
+	p := New[testParams]("/users/{id}/items/{name}")
+
+	assert.Equal(t, true, p.Match("/users/11/items/abc"))
+	assert.Equal(t, true, p.Match("/users/11/items/abc?age=3#top"))
+	assert.Equal(t, true, p.Match(p.Eval(testParams{ID: 11, Name: "a b", Age: 3})))
+
+	assert.Equal(t, false, p.Match("/users/11/items"))
+	assert.Equal(t, false, p.Match("/users/11/items/"))
+	assert.Equal(t, false, p.Match("/users/11/items/abc/def"))
+	assert.Equal(t, false, p.Match("/accounts/11/items/abc"))
+	assert.Equal(t, false, p.Match("/users/11/items/%zz"))
+
+	// static pattern
+	home := New[testParams]("/home.html")
+	assert.Equal(t, true, home.Match("/home.html"))
+	assert.Equal(t, false, home.Match("/homeXhtml"))
+
+	// regexp is compiled once per pattern
+	assert.Same(t, getPatternRegexp("/home.html"), getPatternRegexp("/home.html"))
+}
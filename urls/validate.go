@@ -0,0 +1,375 @@
+package urls
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/QuangTung97/weblib/null"
+)
+
+// FieldError is a validation failure of a single param.
+// Field is the param name, it is empty for errors not belonging to any field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError is returned by Validate, contains all field errors in the order of struct fields
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{
+		Fields: fields,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		if len(field.Field) == 0 {
+			parts = append(parts, field.Message)
+			continue
+		}
+		parts = append(parts, field.Field+": "+field.Message)
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// Get returns the first error message of the field
+func (e *ValidationError) Get(field string) (string, bool) {
+	for _, fieldErr := range e.Fields {
+		if fieldErr.Field == field {
+			return fieldErr.Message, true
+		}
+	}
+	return "", false
+}
+
+// ToMap returns the first error message of each field
+func (e *ValidationError) ToMap() map[string]string {
+	result := make(map[string]string, len(e.Fields))
+	for _, fieldErr := range e.Fields {
+		if _, existed := result[fieldErr.Field]; existed {
+			continue
+		}
+		result[fieldErr.Field] = fieldErr.Message
+	}
+	return result
+}
+
+// Validator is implemented by params types that need custom validation.
+// The returned *ValidationError is merged with errors of the validate tag rules,
+// other errors are considered errors not belonging to any field
+type Validator interface {
+	Validate() error
+}
+
+// Validate checks params using rules of the `validate` tag, with syntax:
+//
+//	validate:"required,min=1,max=100,len=10,oneof=a b c,regexp=^[a-z]+$"
+//
+// regexp= must be the last rule, its value can contain commas.
+// min, max and len are compared with the length of strings and slices, and with the value of numbers.
+// Rules other than required are skipped for missing values: null, empty strings and empty slices.
+// Validate() of the params type is also called if it implements Validator
+func Validate(obj any) error {
+	objValue := reflect.ValueOf(obj)
+	if objValue.Kind() == reflect.Ptr {
+		objValue = objValue.Elem()
+	}
+
+	var fieldErrors []FieldError
+	for jsonTag := range getAllJsonTagsOfValue(objValue) {
+		if jsonTag.err != nil {
+			return jsonTag.err
+		}
+
+		for _, rule := range jsonTag.rules {
+			message, ok := rule.check(jsonTag)
+			if ok {
+				continue
+			}
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   jsonTag.name,
+				Message: message,
+			})
+			break
+		}
+	}
+
+	if validator, ok := obj.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				fieldErrors = append(fieldErrors, validationErr.Fields...)
+			} else {
+				fieldErrors = append(fieldErrors, FieldError{Message: err.Error()})
+			}
+		}
+	}
+
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	return NewValidationError(fieldErrors...)
+}
+
+// ---------------------------------------------------------------------------
+// Internal Implementation
+// ---------------------------------------------------------------------------
+
+type validateRuleKind int
+
+const (
+	ruleRequired validateRuleKind = iota + 1
+	ruleMin
+	ruleMax
+	ruleLen
+	ruleOneOf
+	ruleRegexp
+)
+
+// measureKind is how min, max and len rules measure a value
+type measureKind int
+
+const (
+	measureNone measureKind = iota
+	measureNumber
+	measureString
+	measureSlice
+)
+
+type validateRule struct {
+	kind validateRuleKind
+	arg  string
+
+	measure measureKind
+	number  float64
+	choices []string
+	pattern *regexp.Regexp
+}
+
+type fieldValidateRules struct {
+	rules []validateRule
+	err   error
+}
+
+// validateRulesCache maps reflect.Type of structs to []fieldValidateRules, indexed by field index
+var validateRulesCache sync.Map
+
+// getValidateRules returns the parsed rules of the field at index, the rules are parsed once per struct type
+func getValidateRules(structType reflect.Type, index int) ([]validateRule, error) {
+	cached, ok := validateRulesCache.Load(structType)
+	if !ok {
+		fields := make([]fieldValidateRules, structType.NumField())
+		for i := range fields {
+			rules, err := parseValidateRules(structType.Field(i), structType)
+			fields[i] = fieldValidateRules{rules: rules, err: err}
+		}
+		cached, _ = validateRulesCache.LoadOrStore(structType, fields)
+	}
+
+	field := cached.([]fieldValidateRules)[index]
+	return field.rules, field.err
+}
+
+func parseValidateRules(field reflect.StructField, structType reflect.Type) ([]validateRule, error) {
+	tag := field.Tag.Get("validate")
+	if len(tag) == 0 {
+		return nil, nil
+	}
+
+	newErr := func(format string, args ...any) error {
+		return fmt.Errorf(
+			"%s in validate tag of field '%s' in struct '%s'",
+			fmt.Sprintf(format, args...), field.Name, structType.Name(),
+		)
+	}
+
+	measure := getMeasureKind(field.Type)
+
+	var rules []validateRule
+	for len(tag) > 0 {
+		if pattern, ok := strings.CutPrefix(tag, "regexp="); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, newErr("invalid regexp '%s'", pattern)
+			}
+			rules = append(rules, validateRule{kind: ruleRegexp, arg: pattern, pattern: re})
+			break
+		}
+
+		var ruleStr string
+		ruleStr, tag, _ = strings.Cut(tag, ",")
+		name, arg, _ := strings.Cut(ruleStr, "=")
+
+		rule := validateRule{arg: arg, measure: measure}
+		switch name {
+		case "required":
+			rule.kind = ruleRequired
+
+		case "min", "max", "len":
+			rule.kind = getSizeRuleKind(name)
+			if measure == measureNone || (rule.kind == ruleLen && measure == measureNumber) {
+				return nil, newErr("rule '%s' is not supported for type '%s'", name, field.Type.String())
+			}
+			num, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return nil, newErr("invalid number '%s' of rule '%s'", arg, name)
+			}
+			rule.number = num
+
+		case "oneof":
+			rule.kind = ruleOneOf
+			rule.choices = strings.Fields(arg)
+			if len(rule.choices) == 0 {
+				return nil, newErr("missing values of rule 'oneof'")
+			}
+
+		default:
+			return nil, newErr("unknown rule '%s'", name)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func getSizeRuleKind(name string) validateRuleKind {
+	switch name {
+	case "min":
+		return ruleMin
+	case "max":
+		return ruleMax
+	default:
+		return ruleLen
+	}
+}
+
+func getMeasureKind(fieldType reflect.Type) measureKind {
+	if output, ok := null.IsNullType(reflect.New(fieldType).Elem()); ok {
+		fieldType = output.DataField.Type()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if fieldType == durationType {
+			return measureNone
+		}
+		return measureNumber
+
+	case reflect.String:
+		return measureString
+
+	case reflect.Slice:
+		return measureSlice
+
+	default:
+		return measureNone
+	}
+}
+
+// check returns the error message when the value is invalid
+func (r validateRule) check(jsonTag jsonTagValue) (string, bool) {
+	if r.kind == ruleRequired {
+		if jsonTag.isZero {
+			return "is required", false
+		}
+		return "", true
+	}
+
+	if isMissingValue(jsonTag) {
+		return "", true
+	}
+
+	switch r.kind {
+	case ruleMin, ruleMax, ruleLen:
+		return r.checkSize(jsonTag)
+
+	case ruleOneOf:
+		for _, value := range getCheckedStrings(jsonTag) {
+			if !slices.Contains(r.choices, value) {
+				return "must be one of: " + strings.Join(r.choices, ", "), false
+			}
+		}
+		return "", true
+
+	default:
+		for _, value := range getCheckedStrings(jsonTag) {
+			if !r.pattern.MatchString(value) {
+				return "has invalid format", false
+			}
+		}
+		return "", true
+	}
+}
+
+func (r validateRule) checkSize(jsonTag jsonTagValue) (string, bool) {
+	var size float64
+	var unit string
+
+	switch r.measure {
+	case measureString:
+		size = float64(utf8.RuneCountInString(jsonTag.value))
+		unit = " characters"
+	case measureSlice:
+		size = float64(len(jsonTag.values))
+		unit = " items"
+	default:
+		num, err := strconv.ParseFloat(jsonTag.value, 64)
+		if err != nil {
+			return "is not a number", false
+		}
+		size = num
+	}
+
+	switch r.kind {
+	case ruleMin:
+		if size < r.number {
+			return "must be at least " + r.arg + unit, false
+		}
+	case ruleMax:
+		if size > r.number {
+			return "must be at most " + r.arg + unit, false
+		}
+	default:
+		if size != r.number {
+			return "must have exactly " + r.arg + unit, false
+		}
+	}
+	return "", true
+}
+
+// isMissingValue checks whether the value is skipped by rules other than required
+func isMissingValue(jsonTag jsonTagValue) bool {
+	if jsonTag.isSlice {
+		return len(jsonTag.values) == 0
+	}
+
+	fieldVal := jsonTag.fieldValue
+	if output, ok := null.IsNullType(fieldVal); ok {
+		if !output.NonNull {
+			return true
+		}
+		fieldVal = output.DataField
+	}
+	return fieldVal.Kind() == reflect.String && fieldVal.Len() == 0
+}
+
+func getCheckedStrings(jsonTag jsonTagValue) []string {
+	if jsonTag.isSlice {
+		return jsonTag.values
+	}
+	return []string{jsonTag.value}
+}
@@ -0,0 +1,189 @@
+package urls
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/null"
+)
+
+type validateParams struct {
+	Name   string            `json:"name" validate:"required,min=2,max=5"`
+	Age    int               `json:"age" validate:"min=18,max=60"`
+	Code   string            `json:"code" validate:"len=3,regexp=^[A-Z]+$"`
+	Role   string            `json:"role" validate:"oneof=admin member"`
+	Tags   []string          `json:"tag" validate:"max=2,oneof=a b c"`
+	Score  null.Null[int]    `json:"score" validate:"min=1"`
+	Email  null.Null[string] `json:"email" validate:"regexp=^[^@]+@[^@]+$"`
+	Filter struct {
+		Status string `json:"status" validate:"required"`
+	} `json:"filter"`
+}
+
+type passwordParams struct {
+	Password string `json:"password" validate:"required"`
+	Confirm  string `json:"confirm"`
+}
+
+func (p passwordParams) Validate() error {
+	if p.Password != p.Confirm {
+		return NewValidationError(FieldError{Field: "confirm", Message: "does not match"})
+	}
+	return nil
+}
+
+type dateRangeParams struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+func (p *dateRangeParams) Validate() error {
+	if p.From > p.To {
+		return errors.New("invalid date range")
+	}
+	return nil
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		params := validateParams{
+			Name:  "user",
+			Age:   20,
+			Code:  "ABC",
+			Role:  "admin",
+			Tags:  []string{"a", "c"},
+			Score: null.New(3),
+			Email: null.New("user@example.com"),
+		}
+		params.Filter.Status = "active"
+
+		assert.Equal(t, nil, Validate(params))
+		assert.Equal(t, nil, Validate(&params))
+	})
+
+	t.Run("missing values", func(t *testing.T) {
+		err := Validate(validateParams{})
+		assert.Equal(t, NewValidationError(
+			FieldError{Field: "name", Message: "is required"},
+			FieldError{Field: "age", Message: "must be at least 18"},
+			FieldError{Field: "filter.status", Message: "is required"},
+		), err)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		params := validateParams{
+			Name:  "username",
+			Age:   61,
+			Code:  "AB",
+			Role:  "guest",
+			Tags:  []string{"a", "d"},
+			Score: null.New(0),
+			Email: null.New("invalid"),
+		}
+		params.Filter.Status = "active"
+
+		err := Validate(params)
+		assert.Equal(t, NewValidationError(
+			FieldError{Field: "name", Message: "must be at most 5 characters"},
+			FieldError{Field: "age", Message: "must be at most 60"},
+			FieldError{Field: "code", Message: "must have exactly 3 characters"},
+			FieldError{Field: "role", Message: "must be one of: admin, member"},
+			FieldError{Field: "tag", Message: "must be one of: a, b, c"},
+			FieldError{Field: "score", Message: "must be at least 1"},
+			FieldError{Field: "email", Message: "has invalid format"},
+		), err)
+		assert.Equal(t,
+			"validation failed: name: must be at most 5 characters; age: must be at most 60; "+
+				"code: must have exactly 3 characters; role: must be one of: admin, member; "+
+				"tag: must be one of: a, b, c; score: must be at least 1; email: has invalid format",
+			err.Error(),
+		)
+	})
+
+	t.Run("with validate method", func(t *testing.T) {
+		err := Validate(passwordParams{Confirm: "abc"})
+		assert.Equal(t, NewValidationError(
+			FieldError{Field: "password", Message: "is required"},
+			FieldError{Field: "confirm", Message: "does not match"},
+		), err)
+
+		assert.Equal(t, nil, Validate(passwordParams{Password: "abc", Confirm: "abc"}))
+	})
+
+	t.Run("with validate method returning normal error", func(t *testing.T) {
+		err := Validate(&dateRangeParams{From: 3, To: 2})
+		assert.Equal(t, NewValidationError(
+			FieldError{Message: "invalid date range"},
+		), err)
+		assert.Equal(t, "validation failed: invalid date range", err.Error())
+	})
+
+	t.Run("to map", func(t *testing.T) {
+		err := NewValidationError(
+			FieldError{Field: "name", Message: "is required"},
+			FieldError{Field: "name", Message: "is too long"},
+			FieldError{Field: "age", Message: "is invalid"},
+		)
+		assert.Equal(t, map[string]string{
+			"name": "is required",
+			"age":  "is invalid",
+		}, err.ToMap())
+
+		msg, ok := err.Get("age")
+		assert.Equal(t, true, ok)
+		assert.Equal(t, "is invalid", msg)
+
+		_, ok = err.Get("unknown")
+		assert.Equal(t, false, ok)
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		type unknownRule struct {
+			Name string `json:"name" validate:"email"`
+		}
+		assert.PanicsWithValue(t,
+			"unknown rule 'email' in validate tag of field 'Name' in struct 'unknownRule'",
+			func() { New[unknownRule]("/home") },
+		)
+
+		type invalidNumber struct {
+			Name string `json:"name" validate:"min=abc"`
+		}
+		assert.PanicsWithValue(t,
+			"invalid number 'abc' of rule 'min' in validate tag of field 'Name' in struct 'invalidNumber'",
+			func() { New[invalidNumber]("/home") },
+		)
+
+		type lenOfNumber struct {
+			Age int `json:"age" validate:"len=2"`
+		}
+		assert.PanicsWithValue(t,
+			"rule 'len' is not supported for type 'int' in validate tag of field 'Age' in struct 'lenOfNumber'",
+			func() { New[lenOfNumber]("/home") },
+		)
+
+		type invalidRegexp struct {
+			Name string `json:"name" validate:"regexp=[a-z"`
+		}
+		assert.PanicsWithValue(t,
+			"invalid regexp '[a-z' in validate tag of field 'Name' in struct 'invalidRegexp'",
+			func() { New[invalidRegexp]("/home") },
+		)
+	})
+}
+
+func TestValidate__Rules_Parsed_Once(t *testing.T) {
+	structType := reflect.TypeFor[validateParams]()
+
+	rules1, err := getValidateRules(structType, 2)
+	assert.Equal(t, nil, err)
+
+	rules2, err := getValidateRules(structType, 2)
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, 2, len(rules1))
+	assert.Same(t, rules1[1].pattern, rules2[1].pattern)
+}
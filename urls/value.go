@@ -0,0 +1,104 @@
+package urls
+
+import (
+	"encoding"
+	"reflect"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Internal Implementation
+// ---------------------------------------------------------------------------
+
+// fieldOptions are options from struct tags, used for converting between field values and strings
+type fieldOptions struct {
+	timeLayout string // from tag `layout:"..."`, default is time.RFC3339
+}
+
+func getFieldOptions(field reflect.StructField) fieldOptions {
+	return fieldOptions{
+		timeLayout: field.Tag.Get("layout"),
+	}
+}
+
+func (o fieldOptions) getTimeLayout() string {
+	if len(o.timeLayout) == 0 {
+		return time.RFC3339
+	}
+	return o.timeLayout
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// specialValueToString converts time.Time, time.Duration and encoding.TextMarshaler types.
+// handled = false means the value should be converted based on its kind
+func specialValueToString(val reflect.Value, options fieldOptions) (str string, handled bool) {
+	valType := val.Type()
+	if valType == durationType {
+		return time.Duration(val.Int()).String(), true
+	}
+
+	if !val.CanInterface() {
+		return "", false
+	}
+
+	if valType == timeType {
+		return val.Interface().(time.Time).Format(options.getTimeLayout()), true
+	}
+
+	if !reflect.PointerTo(valType).Implements(textMarshalerType) {
+		return "", false
+	}
+
+	// copy to a new pointer for supporting methods with pointer receiver
+	ptr := reflect.New(valType)
+	ptr.Elem().Set(val)
+
+	data, err := ptr.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// specialValueFromString is the reverse of specialValueToString
+func specialValueFromString(val reflect.Value, str string, options fieldOptions) (ok bool, handled bool) {
+	valType := val.Type()
+
+	switch valType {
+	case timeType:
+		t, err := time.Parse(options.getTimeLayout(), str)
+		if err != nil {
+			return false, true
+		}
+		val.Set(reflect.ValueOf(t))
+		return true, true
+
+	case durationType:
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return false, true
+		}
+		val.SetInt(int64(d))
+		return true, true
+
+	default:
+	}
+
+	if !reflect.PointerTo(valType).Implements(textUnmarshalerType) {
+		return false, false
+	}
+
+	ptr := reflect.New(valType)
+	if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+		return false, true
+	}
+	val.Set(ptr.Elem())
+	return true, true
+}
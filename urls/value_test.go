@@ -0,0 +1,145 @@
+package urls
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/QuangTung97/weblib/null"
+)
+
+type testStatus int
+
+const (
+	testStatusActive testStatus = iota + 1
+	testStatusInactive
+)
+
+func (s testStatus) MarshalText() ([]byte, error) {
+	switch s {
+	case testStatusActive:
+		return []byte("active"), nil
+	case testStatusInactive:
+		return []byte("inactive"), nil
+	default:
+		return nil, fmt.Errorf("invalid status: %d", int(s))
+	}
+}
+
+func (s *testStatus) UnmarshalText(data []byte) error {
+	switch string(data) {
+	case "active":
+		*s = testStatusActive
+	case "inactive":
+		*s = testStatusInactive
+	default:
+		return fmt.Errorf("invalid status: %s", string(data))
+	}
+	return nil
+}
+
+type valueParams struct {
+	ID       int                   `json:"id"`
+	Price    float64               `json:"price"`
+	Ratio    float32               `json:"ratio"`
+	From     time.Time             `json:"from"`
+	Day      time.Time             `json:"day" layout:"2006-01-02"`
+	Timeout  time.Duration         `json:"timeout"`
+	Status   testStatus            `json:"status"`
+	Statuses []testStatus          `json:"statuses"`
+	Until    null.Null[time.Time]  `json:"until" layout:"2006-01-02"`
+	Limit    null.Null[float64]    `json:"limit"`
+	Owner    null.Null[testStatus] `json:"owner"`
+}
+
+func TestPath__Value_Params(t *testing.T) {
+	t.Run("eval", func(t *testing.T) {
+		p := New[valueParams]("/items/{id}")
+		newURL := p.Eval(valueParams{
+			ID:       11,
+			Price:    12.5,
+			Ratio:    0.1,
+			From:     time.Date(2025, 3, 4, 10, 20, 30, 0, time.UTC),
+			Day:      time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC),
+			Timeout:  90 * time.Second,
+			Status:   testStatusActive,
+			Statuses: []testStatus{testStatusInactive, testStatusActive},
+			Until:    null.New(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)),
+			Limit:    null.New(2.25),
+		})
+		assert.Equal(t,
+			"/items/11?day=2025-03-05&from=2025-03-04T10%3A20%3A30Z&limit=2.25&price=12.5&ratio=0.1"+
+				"&status=active&statuses=inactive&statuses=active&timeout=1m30s&until=2025-04-01",
+			newURL,
+		)
+	})
+
+	t.Run("eval zero values", func(t *testing.T) {
+		p := New[valueParams]("/items/{id}")
+		newURL := p.Eval(valueParams{ID: 11})
+		assert.Equal(t, "/items/11", newURL)
+	})
+
+	t.Run("set values", func(t *testing.T) {
+		values := map[string]string{
+			"price":   "12.5",
+			"ratio":   "0.25",
+			"from":    "2025-03-04T10:20:30Z",
+			"day":     "2025-03-05",
+			"timeout": "1m30s",
+			"status":  "inactive",
+			"until":   "2025-04-01",
+			"limit":   "3",
+			"owner":   "active",
+		}
+
+		var params valueParams
+		err := SetStructWithValueList(
+			&params,
+			[]string{"price", "ratio", "from", "day", "timeout", "status", "statuses", "until", "limit", "owner"},
+			func(name string) []string {
+				if name == "statuses" {
+					return []string{"active", "inactive"}
+				}
+				return []string{values[name]}
+			},
+		)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, valueParams{
+			Price:    12.5,
+			Ratio:    0.25,
+			From:     time.Date(2025, 3, 4, 10, 20, 30, 0, time.UTC),
+			Day:      time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC),
+			Timeout:  90 * time.Second,
+			Status:   testStatusInactive,
+			Statuses: []testStatus{testStatusActive, testStatusInactive},
+			Until:    null.New(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)),
+			Limit:    null.New(3.0),
+			Owner:    null.New(testStatusActive),
+		}, params)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		cases := []struct {
+			name  string
+			value string
+			err   string
+		}{
+			{name: "price", value: "abc", err: "can not set value 'abc' to field 'price' with type 'float64'"},
+			{name: "day", value: "2025-03-05T00:00:00Z", err: "can not set value '2025-03-05T00:00:00Z' to field 'day' with type 'time.Time'"},
+			{name: "timeout", value: "10", err: "can not set value '10' to field 'timeout' with type 'time.Duration'"},
+			{name: "status", value: "deleted", err: "can not set value 'deleted' to field 'status' with type 'urls.testStatus'"},
+		}
+		for _, c := range cases {
+			var params valueParams
+			err := SetStructWithValues(&params, []string{c.name}, func(name string) string {
+				return c.value
+			})
+			assert.Equal(t, errors.New(c.err), err)
+			assert.Equal(t, valueParams{}, params)
+		}
+	})
+}